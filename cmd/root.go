@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/asherda/lightwalletd/common"
@@ -32,6 +34,10 @@ import (
 var cfgFile string
 var logger = logrus.New()
 
+// How long a shutdown waits for the block ingestor, and then for in-flight
+// gRPC calls (such as GetBlockRange and GetMempoolStream streams), to finish.
+const shutdownTimeout = 10 * time.Second
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "lightwalletd",
@@ -52,6 +58,8 @@ var rootCmd = &cobra.Command{
 			RPCPassword:         viper.GetString("rpcpassword"),
 			RPCHost:             viper.GetString("rpchost"),
 			RPCPort:             viper.GetString("rpcport"),
+			ZMQHashBlockAddr:    viper.GetString("zmq-hashblock"),
+			ZMQRawTxAddr:        viper.GetString("zmq-rawtx"),
 			NoTLSVeryInsecure:   viper.GetBool("no-tls-very-insecure"),
 			GenCertVeryInsecure: viper.GetBool("gen-cert-very-insecure"),
 			DataDir:             viper.GetString("data-dir"),
@@ -59,6 +67,11 @@ var rootCmd = &cobra.Command{
 			PingEnable:          viper.GetBool("ping-very-insecure"),
 			Darkside:            viper.GetBool("darkside-very-insecure"),
 			DarksideTimeout:     viper.GetUint64("darkside-timeout"),
+			SyncWorkers:         viper.GetInt("sync-workers"),
+			MaxOutage:           viper.GetUint64("max-outage"),
+			MaxSyncLag:          viper.GetInt("max-sync-lag"),
+			ValidateBlocks:      viper.GetBool("validate-blocks"),
+			CompactBlockHeaders: viper.GetBool("compact-block-headers"),
 		}
 
 		common.Log.Debugf("Options: %#v\n", opts)
@@ -102,17 +115,26 @@ func fileExists(filename string) bool {
 }
 
 func startServer(opts *common.Options) error {
+	// The signal handler replaces logOutput on SIGHUP.
+	var logOutput *os.File
+	var logOutputMutex sync.Mutex
 	if opts.LogFile != "" {
 		// instead write parsable logs for logstash/splunk/etc
-		output, err := os.OpenFile(opts.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		var err error
+		logOutput, err = os.OpenFile(opts.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 		if err != nil {
 			common.Log.WithFields(logrus.Fields{
 				"error": err,
 				"path":  opts.LogFile,
 			}).Fatal("couldn't open log file")
 		}
-		defer output.Close()
-		logger.SetOutput(output)
+		defer func() {
+			logOutputMutex.Lock()
+			defer logOutputMutex.Unlock()
+			logOutput.Close()
+			logOutput = nil
+		}()
+		logger.SetOutput(logOutput)
 		logger.SetFormatter(&logrus.JSONFormatter{})
 	}
 
@@ -171,7 +193,15 @@ func startServer(opts *common.Options) error {
 	}
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	grpc_prometheus.Register(server)
-	go startHTTPServer(opts)
+
+	// Health checking, over gRPC and http
+	services := []string{walletrpc.CompactTxStreamer_ServiceDesc.ServiceName}
+	if opts.Darkside {
+		services = append(services, walletrpc.DarksideStreamer_ServiceDesc.ServiceName)
+	}
+	healthServer := frontend.NewHealthServer(services...)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go startHTTPServer(opts, healthServer)
 
 	// Enable reflection for debugging
 	if opts.LogLevel >= uint64(logrus.WarnLevel) {
@@ -237,10 +267,14 @@ func startServer(opts *common.Options) error {
 
 	// leveldb instances are safe for concurrent use.
 	db, err := leveldb.OpenFile(dbPath, nil)
-	defer db.Close()
 
 	cache := common.NewBlockCache(db, chainID, saplingHeight, opts.Redownload)
+	common.MaxNodeOutage = time.Duration(opts.MaxOutage) * time.Minute
+	common.MaxSyncLag = opts.MaxSyncLag
 	if !opts.Darkside {
+		common.SyncWorkers = opts.SyncWorkers
+		common.ValidateBlocks = opts.ValidateBlocks
+		common.StartZMQ(opts.ZMQHashBlockAddr, opts.ZMQRawTxAddr)
 		go common.BlockIngestor(cache, 0 /*loop forever*/)
 	} else {
 		// Darkside wants to control starting the block ingestor.
@@ -249,7 +283,7 @@ func startServer(opts *common.Options) error {
 
 	// Compact transaction service initialization
 	{
-		service, err := frontend.NewLwdStreamer(cache, chainName, opts.PingEnable)
+		service, err := frontend.NewLwdStreamer(cache, chainName, opts.PingEnable, opts.CompactBlockHeaders)
 		if err != nil {
 			common.Log.WithFields(logrus.Fields{
 				"error": err,
@@ -266,6 +300,12 @@ func startServer(opts *common.Options) error {
 		}
 		walletrpc.RegisterDarksideStreamerServer(server, service)
 	}
+	if opts.Darkside {
+		// There's no real zcashd to wait for.
+		healthServer.SetReady(nil)
+	} else {
+		go common.HealthWatcher(cache, healthServer.SetReady, 0 /*loop forever*/)
+	}
 
 	// Start listening
 	listener, err := net.Listen("tcp", opts.GRPCBindAddr)
@@ -276,16 +316,27 @@ func startServer(opts *common.Options) error {
 		}).Fatal("couldn't create listener")
 	}
 
-	// Signal handler for graceful stops
+	// Signal handler for graceful stops, and for reopening the log file
+	// (after logrotate has moved it)
 	signals := make(chan os.Signal, 1)
-	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	ingestorStopped := make(chan bool, 1)
 	go func() {
-		s := <-signals
-		cache.Sync()
-		common.Log.WithFields(logrus.Fields{
-			"signal": s.String(),
-		}).Info("caught signal, stopping gRPC server")
-		os.Exit(1)
+		for s := range signals {
+			if s == syscall.SIGHUP {
+				logOutputMutex.Lock()
+				if logOutput != nil {
+					logOutput = reopenLogFile(opts.LogFile, logOutput)
+				}
+				logOutputMutex.Unlock()
+				continue
+			}
+			common.Log.WithFields(logrus.Fields{
+				"signal": s.String(),
+			}).Info("caught signal, stopping gRPC server")
+			ingestorStopped <- shutdown(server, healthServer, opts.Darkside)
+			return
+		}
 	}()
 
 	err = server.Serve(listener)
@@ -294,9 +345,63 @@ func startServer(opts *common.Options) error {
 			"error": err,
 		}).Fatal("gRPC server exited")
 	}
+	// Serve returns without error only once shutdown has stopped the server.
+	if !<-ingestorStopped {
+		// Closing the cache under the ingestor could lose the block it's
+		// adding; leave that to the process exit.
+		common.Log.Warning("block ingestor didn't stop, not closing the cache")
+		return nil
+	}
+	cache.Sync()
+	cache.Close()
+	common.Log.Info("shutdown complete")
 	return nil
 }
 
+// shutdown stops the block ingestor, so that it's not in the middle of adding
+// a block when the cache is closed, then stops the gRPC server, allowing
+// in-flight calls some time to finish. It returns false if the ingestor
+// didn't stop, so the cache mustn't be closed.
+func shutdown(server *grpc.Server, healthServer *frontend.HealthServer, darkside bool) bool {
+	// Tell load balancers to stop sending new calls.
+	healthServer.Shutdown()
+	ingestorStopped := darkside || common.StopBlockIngestor(shutdownTimeout)
+	if !ingestorStopped {
+		common.Log.Warning("block ingestor is busy, shutting down anyway")
+	}
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		common.Log.Warning("gRPC calls still in progress, closing them")
+		server.Stop()
+	}
+	return ingestorStopped
+}
+
+// reopenLogFile opens the log file at the given path (creating it, if
+// logrotate has moved it away), switches logging to it, and closes the old
+// one, returning the new one. If it can't, it keeps using the old one.
+func reopenLogFile(path string, old *os.File) *os.File {
+	output, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		common.Log.WithFields(logrus.Fields{
+			"error": err,
+			"path":  path,
+		}).Warning("couldn't reopen log file")
+		return old
+	}
+	// Once this returns, nothing is writing to the old file.
+	logger.SetOutput(output)
+	old.Close()
+	common.Log.Info("reopened log file")
+	return output
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -322,6 +427,8 @@ func init() {
 	rootCmd.Flags().String("rpcpassword", "", "RPC password")
 	rootCmd.Flags().String("rpchost", "", "RPC host")
 	rootCmd.Flags().String("rpcport", "", "RPC host port")
+	rootCmd.Flags().String("zmq-hashblock", "", "zcashd -zmqpubhashblock endpoint to subscribe to for new blocks (such as tcp://127.0.0.1:28332)")
+	rootCmd.Flags().String("zmq-rawtx", "", "zcashd -zmqpubrawtx endpoint to subscribe to for new mempool transactions")
 	rootCmd.Flags().Bool("no-tls-very-insecure", false, "run without the required TLS certificate, only for debugging, DO NOT use in production")
 	rootCmd.Flags().Bool("gen-cert-very-insecure", false, "run with self-signed TLS certificate, only for debugging, DO NOT use in production")
 	rootCmd.Flags().Bool("redownload", false, "re-fetch all blocks from zcashd; reinitialize local cache files")
@@ -329,6 +436,11 @@ func init() {
 	rootCmd.Flags().Bool("ping-very-insecure", false, "allow Ping GRPC for testing")
 	rootCmd.Flags().Bool("darkside-very-insecure", false, "run with GRPC-controllable mock zcashd for integration testing (shuts down after 30 minutes)")
 	rootCmd.Flags().Int("darkside-timeout", 30, "override 30 minute default darkside timeout")
+	rootCmd.Flags().Int("sync-workers", 4, "number of blocks to fetch from zcashd concurrently while far behind the tip (1 disables)")
+	rootCmd.Flags().Int("max-outage", 30, "exit if zcashd is unavailable for this many minutes (0 means never)")
+	rootCmd.Flags().Int("max-sync-lag", 10, "report not ready (health checks) while the block cache is more than this many blocks behind zcashd")
+	rootCmd.Flags().Bool("compact-block-headers", false, "include the full block header in the compact blocks returned by GetBlock and GetBlockRange")
+	rootCmd.Flags().Bool("validate-blocks", false, "check each block's merkle root, proof of work or stake, height and time before caching it")
 
 	viper.BindPFlag("grpc-bind-addr", rootCmd.Flags().Lookup("grpc-bind-addr"))
 	viper.SetDefault("grpc-bind-addr", "127.0.0.1:9077")
@@ -350,6 +462,8 @@ func init() {
 	viper.BindPFlag("rpcpassword", rootCmd.Flags().Lookup("rpcpassword"))
 	viper.BindPFlag("rpchost", rootCmd.Flags().Lookup("rpchost"))
 	viper.BindPFlag("rpcport", rootCmd.Flags().Lookup("rpcport"))
+	viper.BindPFlag("zmq-hashblock", rootCmd.Flags().Lookup("zmq-hashblock"))
+	viper.BindPFlag("zmq-rawtx", rootCmd.Flags().Lookup("zmq-rawtx"))
 	viper.BindPFlag("no-tls-very-insecure", rootCmd.Flags().Lookup("no-tls-very-insecure"))
 	viper.SetDefault("no-tls-very-insecure", false)
 	viper.BindPFlag("gen-cert-very-insecure", rootCmd.Flags().Lookup("gen-cert-very-insecure"))
@@ -364,6 +478,16 @@ func init() {
 	viper.SetDefault("darkside-very-insecure", false)
 	viper.BindPFlag("darkside-timeout", rootCmd.Flags().Lookup("darkside-timeout"))
 	viper.SetDefault("darkside-timeout", 30)
+	viper.BindPFlag("sync-workers", rootCmd.Flags().Lookup("sync-workers"))
+	viper.SetDefault("sync-workers", 4)
+	viper.BindPFlag("max-outage", rootCmd.Flags().Lookup("max-outage"))
+	viper.SetDefault("max-outage", 30)
+	viper.BindPFlag("max-sync-lag", rootCmd.Flags().Lookup("max-sync-lag"))
+	viper.SetDefault("max-sync-lag", 10)
+	viper.BindPFlag("compact-block-headers", rootCmd.Flags().Lookup("compact-block-headers"))
+	viper.SetDefault("compact-block-headers", false)
+	viper.BindPFlag("validate-blocks", rootCmd.Flags().Lookup("validate-blocks"))
+	viper.SetDefault("validate-blocks", false)
 
 	logger.SetFormatter(&logrus.TextFormatter{
 		//DisableColors:          true,
@@ -384,6 +508,7 @@ func init() {
 	// Indirect functions for test mocking (so unit tests can talk to stub functions)
 	common.Time.Sleep = time.Sleep
 	common.Time.Now = time.Now
+	common.Time.After = time.After
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -411,7 +536,9 @@ func initConfig() {
 
 }
 
-func startHTTPServer(opts *common.Options) {
+func startHTTPServer(opts *common.Options, healthServer *frontend.HealthServer) {
 	http.Handle("/metrics", promhttp.Handler())
+	http.HandleFunc("/healthz", healthServer.Healthz)
+	http.HandleFunc("/readyz", healthServer.Readyz)
 	http.ListenAndServe(opts.HTTPBindAddr, nil)
 }
@@ -4,7 +4,12 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/asherda/lightwalletd/common"
 )
 
 func TestFileExists(t *testing.T) {
@@ -20,3 +25,38 @@ func TestFileExists(t *testing.T) {
 		t.Fatal("fileExists failed")
 	}
 }
+
+func TestReopenLogFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.log")
+	output, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.SetOutput(output)
+	defer logger.SetOutput(os.Stderr)
+	common.Log.Info("before rotation")
+
+	// logrotate moves the file away, then signals
+	if err = os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	output = reopenLogFile(path, output)
+	defer output.Close()
+	common.Log.Info("after rotation")
+
+	rotated, _ := os.ReadFile(path + ".1")
+	current, _ := os.ReadFile(path)
+	if !strings.Contains(string(rotated), "before rotation") ||
+		strings.Contains(string(rotated), "after rotation") {
+		t.Fatalf("unexpected rotated log %q", rotated)
+	}
+	if !strings.Contains(string(current), "after rotation") {
+		t.Fatalf("unexpected log %q", current)
+	}
+
+	// If the file can't be opened, logging continues to the old one.
+	if reopenLogFile(filepath.Join(dir, "nonexistent", "server.log"), output) != output {
+		t.Fatal("reopenLogFile should have kept the old file")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"sync"
+
+	"github.com/asherda/lightwalletd/walletrpc"
+)
+
+// blockSubscriberBuffer is how many events a block subscriber may fall
+// behind by before it's dropped.
+const blockSubscriberBuffer = 100
+
+// BlockEvent is a change to the cache's best chain: either a block added at
+// the tip or, if Block is nil, a reorg that removed the blocks from
+// ReorgHeight on.
+type BlockEvent struct {
+	Block       *walletrpc.CompactBlock
+	ReorgHeight int
+}
+
+// BlockSubscription delivers the cache's BlockEvents, in order, on C. C is
+// closed when the subscription is closed, or if the subscriber falls more
+// than blockSubscriberBuffer events behind (it should then resubscribe).
+type BlockSubscription struct {
+	C   <-chan BlockEvent
+	c   chan BlockEvent
+	hub *blockHub
+}
+
+// blockHub broadcasts BlockEvents to every subscription, without blocking
+// the publisher (the block ingestor).
+type blockHub struct {
+	mutex       sync.Mutex
+	subscribers map[*BlockSubscription]struct{}
+}
+
+func newBlockHub() *blockHub {
+	return &blockHub{subscribers: make(map[*BlockSubscription]struct{})}
+}
+
+func (h *blockHub) subscribe() *BlockSubscription {
+	c := make(chan BlockEvent, blockSubscriberBuffer)
+	sub := &BlockSubscription{C: c, c: c, hub: h}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Caller should hold h.mutex.
+func (h *blockHub) remove(sub *BlockSubscription) {
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.c)
+	}
+}
+
+func (h *blockHub) publish(event BlockEvent) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for sub := range h.subscribers {
+		select {
+		case sub.c <- event:
+		default:
+			Log.Warning("dropping a block subscriber that fell too far behind")
+			h.remove(sub)
+		}
+	}
+}
+
+// Close ends the subscription (and closes C).
+func (sub *BlockSubscription) Close() {
+	sub.hub.mutex.Lock()
+	defer sub.hub.mutex.Unlock()
+	sub.hub.remove(sub)
+}
+
+// Subscribe returns a subscription to the changes to the cache's best chain,
+// and the height of the next block to be added as of subscribing, so the
+// caller can first send the blocks before that from the cache. (Any event
+// that affects those blocks is delivered after subscribing.)
+func (c *BlockCache) Subscribe() (*BlockSubscription, int) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.hub.subscribe(), c.nextBlock
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package common
+
+import (
+	"os"
+	"testing"
+)
+
+func TestBlockSubscription(t *testing.T) {
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+	defer os.RemoveAll(unitTestPath)
+
+	if err := testcache.Add(380640, parseTestBlock(t, 0).ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+	sub, next := testcache.Subscribe()
+	if next != 380641 {
+		t.Fatal("unexpected next height ", next)
+	}
+	slow, _ := testcache.Subscribe()
+	for i := 1; i < 3; i++ {
+		if err := testcache.Add(380640+i, parseTestBlock(t, i).ToCompact()); err != nil {
+			t.Fatal("cache.Add failed:", err)
+		}
+	}
+	testcache.Reorg(380641)
+	for i := 1; i < 3; i++ {
+		event := <-sub.C
+		if event.Block == nil || event.Block.Height != uint64(380640+i) {
+			t.Fatal("unexpected event ", event)
+		}
+	}
+	if event := <-sub.C; event.Block != nil || event.ReorgHeight != 380641 {
+		t.Fatal("unexpected reorg event ", event)
+	}
+
+	// A subscriber that doesn't keep up is dropped (and its channel closed).
+	for i := 0; i < blockSubscriberBuffer; i++ {
+		testcache.Reorg(380640)
+		if err := testcache.Add(380640, parseTestBlock(t, 0).ToCompact()); err != nil {
+			t.Fatal("cache.Add failed:", err)
+		}
+		<-sub.C
+		<-sub.C
+	}
+	count := 0
+	for range slow.C {
+		count++
+	}
+	if count != blockSubscriberBuffer {
+		t.Fatal("unexpected event count ", count)
+	}
+	slow.Close() // no effect
+
+	sub.Close()
+	if _, ok := <-sub.C; ok {
+		t.Fatal("subscription not closed")
+	}
+}
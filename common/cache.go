@@ -8,20 +8,33 @@ package common
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"hash/fnv"
 	"strconv"
 	"sync"
 
+	"github.com/asherda/lightwalletd/parser"
 	"github.com/asherda/lightwalletd/walletrpc"
 	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
+// Results of zcashd rpcs are stored (see PutBlockResult) only as of blocks
+// this close to the tip.
+const blockResultDepth = 100
+
 const (
-	blockHeightPrefix = "B" // key is "B" + block height, value is block; see also H, block by hash
-	blockHashPrefix   = "H" // key is "H" + block hash, value is block; see also B, block by height
+	blockHeightPrefix = "B" // key is "B" + block height, value is block; see also H, height by hash
+	blockHashPrefix   = "H" // key is "H" + block hash, value is block height; see also B, block by height
 	idPrefix          = "I" // key is "I" + chain ID, value is height (more to come), see next (verusID)
+	txidPrefix        = "T" // key is "T" + txid, value is block height and index within the block; see also X
+	blockTxidsPrefix  = "X" // key is "X" + block height, value is the block's txids, in order; see also T
+	blockResultPrefix = "R" // key is "R" + block hash + rpc request, value is the rpc's result as of that block
+	prevoutPrefix     = "O" // key is "O" + txid + output index, value is the output's value and block height
+	blockHeaderPrefix = "D" // key is "D" + block hash, value is the block's serialized header
 )
 
 // BlockCache contains a consecutive set of recent compact blocks in marshalled form.
@@ -32,6 +45,14 @@ type BlockCache struct {
 	latestHash []byte      // hash of the most recent (highest height) block, for detecting reorgs.
 	ldb        *leveldb.DB // levelDB connection
 	mutex      sync.RWMutex
+
+	// Results of zcashd rpcs that depend on the best chain (such as
+	// getidentity), valid only while resultsHash is the latest block hash.
+	results     map[string]json.RawMessage
+	resultsHash []byte
+
+	// Subscribers to the blocks added and removed.
+	hub *blockHub
 }
 
 // GetNextHeight returns the height of the lowest unobtained block.
@@ -152,8 +173,14 @@ func (c *BlockCache) setLatestHash() {
 // Reset is used only for darkside testing.
 func (c *BlockCache) Reset(startHeight int) {
 	c.setDbHeight(c.firstBlock) // empty the cache
+	removed := c.firstBlock
+	if startHeight < removed {
+		removed = startHeight
+	}
 	c.firstBlock = startHeight
 	c.nextBlock = startHeight
+	c.results = nil
+	c.hub.publish(BlockEvent{ReorgHeight: removed})
 }
 
 // NewBlockCache returns an instance of a block cache object.
@@ -163,7 +190,7 @@ func (c *BlockCache) Reset(startHeight int) {
 // Multichain may go to per chain DB, so each cache has a levelDB connection
 // for it's own DB & we can do multiple chains in a single lwd easily.
 func NewBlockCache(db *leveldb.DB, chainID string, startHeight int, redownload bool) *BlockCache {
-	c := &BlockCache{}
+	c := &BlockCache{hub: newBlockHub()}
 	c.verusID = chainID
 	c.ldb = db
 	c.firstBlock = startHeight
@@ -194,6 +221,13 @@ func NewBlockCache(db *leveldb.DB, chainID string, startHeight int, redownload b
 			c.recoverFromCorruption(c.nextBlock)
 			break
 		}
+		if block.ProtoVersion < parser.CompactBlockVersion {
+			// Stored by an older lightwalletd, so (for example) it lacks
+			// transparent inputs and outputs.
+			Log.Warning("blocks cache is in an older format, redownloading")
+			c.flushBlocks(c.firstBlock, c.nextBlock)
+			break
+		}
 	}
 	Log.Info("Found ", c.nextBlock-c.firstBlock, " blocks in cache")
 	return c
@@ -202,6 +236,14 @@ func NewBlockCache(db *leveldb.DB, chainID string, startHeight int, redownload b
 // Add adds the given block to the cache at the given height, returning true
 // if a reorg was detected.
 func (c *BlockCache) Add(height int, block *walletrpc.CompactBlock) error {
+	return c.AddWithTxids(height, block, nil, nil)
+}
+
+// AddWithTxids is like Add, but also records the location of each of the
+// block's transactions, given by txids (all of them, in block order, little-endian
+// wire order), so they can be found by GetTxLocation, and the block's
+// serialized header (if not nil), for GetHeader.
+func (c *BlockCache) AddWithTxids(height int, block *walletrpc.CompactBlock, txids [][]byte, header []byte) error {
 	// Invariant: m[firstBlock..nextBlock) are valid.
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -236,14 +278,26 @@ func (c *BlockCache) Add(height int, block *walletrpc.CompactBlock) error {
 	}
 	checkSummed := checksum(height, data)
 	checkSummed = append(checkSummed, data...)
-	err = c.storeNewBlock(height, checkSummed)
+	// A failed write leaves nextBlock unchanged, so the caller can retry.
+	err = c.storeNewBlock(height, block.Hash, checkSummed)
 	if err != nil {
-		Log.Fatal("hash write at height", height, "failed: ", err)
+		return errors.Wrapf(err, "block write at height %d failed", height)
 	}
-	err = c.storeNewHeight(false)
-
+	if txids != nil {
+		err = c.storeNewTxids(height, txids)
+		if err != nil {
+			return errors.Wrapf(err, "txid write at height %d failed", height)
+		}
+	}
+	if header != nil {
+		err = c.ldb.Put(headerKey(block.Hash), header, &opt.WriteOptions{Sync: false})
+		if err != nil {
+			return errors.Wrapf(err, "header write at height %d failed", height)
+		}
+	}
+	err = c.storeNewOutputs(height, block)
 	if err != nil {
-		Log.Fatal("height write with height", height, "failed: ", err)
+		return errors.Wrapf(err, "output write at height %d failed", height)
 	}
 
 	if c.latestHash == nil {
@@ -251,6 +305,21 @@ func (c *BlockCache) Add(height int, block *walletrpc.CompactBlock) error {
 	}
 	copy(c.latestHash, block.Hash)
 	c.nextBlock++
+	c.hub.publish(BlockEvent{Block: block})
+
+	// Results as of blocks that are now too far from the tip aren't needed.
+	if old := c.nextBlock - 1 - blockResultDepth; old >= c.firstBlock {
+		if oldBlock := c.readBlock(old); oldBlock != nil {
+			c.flushBlockResults(oldBlock.Hash)
+		}
+	}
+
+	// The high water mark is the height of the next block to add.
+	// (If this fails, the next one, or Sync(), will record it.)
+	err = c.storeNewHeight(false)
+	if err != nil {
+		Log.Warning("height write with height ", height, " failed: ", err)
+	}
 	// Invariant: m[firstBlock..nextBlock) are valid.
 	return nil
 }
@@ -269,12 +338,17 @@ func (c *BlockCache) Reorg(height int) {
 		// Timing window, ignore this request
 		return
 	}
-	// Remove the end of the cache.
-	c.flushBlocks(height+1, c.nextBlock)
+	// Remove the end of the cache, including the block at this height: it's
+	// no longer in the cache (nextBlock becomes height), so its hash, txid
+	// and output index entries must go, and the stored height mustn't
+	// include it (else a restart would bring it back).
+	c.flushBlocks(height, c.nextBlock)
 
 	// adjust to the new height
 	c.nextBlock = height
 	c.setLatestHash()
+	c.results = nil
+	c.hub.publish(BlockEvent{ReorgHeight: height})
 }
 
 // Get returns the compact block at the requested height if it's
@@ -299,6 +373,113 @@ func (c *BlockCache) Get(height int) *walletrpc.CompactBlock {
 	return block
 }
 
+// GetHeightByHash returns the height of the block with the given hash
+// (little-endian wire order, as in CompactBlock.Hash), or -1 if that
+// block isn't in the cache.
+func (c *BlockCache) GetHeightByHash(hash []byte) int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.readHeightByHash(hash)
+}
+
+// GetByHash returns the compact block with the given hash (little-endian
+// wire order) if it's in the cache, else nil.
+func (c *BlockCache) GetByHash(hash []byte) *walletrpc.CompactBlock {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	height := c.readHeightByHash(hash)
+	if height < 0 {
+		return nil
+	}
+	return c.readBlock(height)
+}
+
+// Caller should hold (at least) c.mutex.RLock().
+func (c *BlockCache) readHeightByHash(hash []byte) int {
+	if c.ldb == nil || len(hash) == 0 {
+		return -1
+	}
+	data, err := c.ldb.Get(hashKey(hash), nil)
+	if err != nil || len(data) != 8 {
+		return -1
+	}
+	height := int(binary.LittleEndian.Uint64(data))
+	if height < c.firstBlock || height >= c.nextBlock {
+		return -1
+	}
+	// The index entry may be left over from a block that has since been
+	// replaced (reorg, corruption recovery); only trust it if it agrees.
+	block := c.readBlock(height)
+	if block == nil || !bytes.Equal(block.Hash, hash) {
+		return -1
+	}
+	return height
+}
+
+// GetTxLocation returns the height of the block containing the transaction
+// with the given txid (little-endian wire order) and its index within that
+// block, or -1, -1 if the transaction isn't in any cached block (or its
+// block was added without its txids).
+func (c *BlockCache) GetTxLocation(txid []byte) (int, int) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.readTxLocation(txid)
+}
+
+// Caller should hold (at least) c.mutex.RLock().
+func (c *BlockCache) readTxLocation(txid []byte) (int, int) {
+	if c.ldb == nil || len(txid) != 32 {
+		return -1, -1
+	}
+	data, err := c.ldb.Get(txidKey(txid), nil)
+	if err != nil || len(data) != 16 {
+		return -1, -1
+	}
+	height := int(binary.LittleEndian.Uint64(data[:8]))
+	index := int(binary.LittleEndian.Uint64(data[8:]))
+	if height < c.firstBlock || height >= c.nextBlock {
+		return -1, -1
+	}
+	// As with the hash index, only trust the entry if the block's txid
+	// list (which is replaced along with the block) agrees.
+	txids := c.readTxids(height)
+	if index >= len(txids) || !bytes.Equal(txids[index], txid) {
+		return -1, -1
+	}
+	return height, index
+}
+
+// Caller should hold (at least) c.mutex.RLock().
+func (c *BlockCache) readTxids(height int) [][]byte {
+	data, err := c.ldb.Get([]byte(blockTxidsPrefix+strconv.Itoa(height)), nil)
+	if err != nil || len(data)%32 != 0 {
+		return nil
+	}
+	txids := make([][]byte, len(data)/32)
+	for i := range txids {
+		txids[i] = data[i*32 : (i+1)*32]
+	}
+	return txids
+}
+
+// GetHeader returns the serialized header of the cached block with the given
+// hash (little-endian wire order), or nil if it isn't cached (or was added
+// without its header). The compact blocks are cached without their headers,
+// which most clients don't want.
+func (c *BlockCache) GetHeader(hash []byte) []byte {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if c.ldb == nil {
+		return nil
+	}
+	header, err := c.ldb.Get(headerKey(hash), nil)
+	if err != nil {
+		return nil
+	}
+	return header
+}
+
 // GetLatestHeight returns the height of the most recent block, or -1
 // if the cache is empty.
 func (c *BlockCache) GetLatestHeight() int {
@@ -310,6 +491,85 @@ func (c *BlockCache) GetLatestHeight() int {
 	return c.nextBlock - 1
 }
 
+// GetResult returns the cached result of the zcashd rpc with the given key
+// (method and parameters), or nil if it isn't cached as of the latest block.
+// It also returns the latest block hash, which should be passed to PutResult.
+func (c *BlockCache) GetResult(key string) (json.RawMessage, []byte) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	hash := make([]byte, len(c.latestHash))
+	copy(hash, c.latestHash)
+	if c.results == nil || !bytes.Equal(c.resultsHash, c.latestHash) {
+		return nil, hash
+	}
+	return c.results[key], hash
+}
+
+// PutResult caches the result of the zcashd rpc with the given key until the
+// next block (or reorg). The hash is as returned by GetResult before making
+// the rpc; if a block has arrived since, the result may be stale, so it isn't
+// cached.
+func (c *BlockCache) PutResult(key string, result json.RawMessage, hash []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !bytes.Equal(hash, c.latestHash) {
+		return
+	}
+	if c.results == nil || !bytes.Equal(c.resultsHash, c.latestHash) {
+		c.results = make(map[string]json.RawMessage)
+		c.resultsHash = hash
+	}
+	c.results[key] = result
+}
+
+// GetBlockResult returns the stored result of the given zcashd rpc request
+// (method and parameters) as of the block at the given height, or nil if
+// there isn't one, along with that block's hash, which should be passed to
+// PutBlockResult. The hash is nil if the block isn't in the cache.
+func (c *BlockCache) GetBlockResult(height int, request string) (json.RawMessage, []byte) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if height < c.firstBlock || height >= c.nextBlock {
+		return nil, nil
+	}
+	block := c.readBlock(height)
+	if block == nil {
+		return nil, nil
+	}
+	result, err := c.ldb.Get(blockResultKey(block.Hash, request), nil)
+	if err != nil {
+		return nil, block.Hash
+	}
+	return result, block.Hash
+}
+
+// PutBlockResult stores the result of the given zcashd rpc request as of the
+// block with the given height and hash, as long as that block is still in the
+// best chain (and near the tip). The result is removed when the block is
+// (by a reorg), or when the block is blockResultDepth blocks from the tip.
+func (c *BlockCache) PutBlockResult(height int, hash []byte, request string, result json.RawMessage) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if height < c.firstBlock || height >= c.nextBlock || height < c.nextBlock-blockResultDepth {
+		return
+	}
+	if block := c.readBlock(height); block == nil || !bytes.Equal(block.Hash, hash) {
+		return
+	}
+	err := c.ldb.Put(blockResultKey(hash, request), result, &opt.WriteOptions{Sync: false})
+	if err != nil {
+		Log.Warning("error storing rpc result at height: ", height, " ", err)
+	}
+}
+
+// FlushBlockResults removes all stored zcashd rpc results; it's used only
+// for darkside testing, when the mock zcashd's replies change.
+func (c *BlockCache) FlushBlockResults() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.flushBlockResults(nil)
+}
+
 // Sync ensures that the db files are flushed to disk, can be called unnecessarily.
 func (c *BlockCache) Sync() {
 	c.storeNewHeight(true)
@@ -317,6 +577,9 @@ func (c *BlockCache) Sync() {
 
 // Close is Currently used only for testing.
 func (c *BlockCache) Close() {
+	// Don't close the db in the middle of an Add().
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	// Some operating system require you to close files before you can remove them.
 	if c.ldb != nil {
 		c.ldb.Close()
@@ -332,41 +595,169 @@ func (c *BlockCache) flushBlocks(height int, last int) {
 }
 
 func (c *BlockCache) flushBlock(height int) {
-	key := []byte(blockHashPrefix + strconv.Itoa(height))
-	// lets sync these, want deleted items to stay deleted even if we crash
-	err := c.ldb.Delete(key, &opt.WriteOptions{Sync: false})
-	if err != nil {
-		Log.Warning("error flushing block at height: ", err)
-		if c.latestHash != nil {
-			var hashID = make([]byte, 33)
-			copy(hashID, blockHashPrefix)
-			hashID = append(hashID, c.latestHash...)
-			err = c.ldb.Delete(hashID, &opt.WriteOptions{Sync: false})
-			if err != nil {
-				Log.Warning("flushing block by hash at height: ", err)
+	// Drop the hash index entry (and rpc results) first; that requires
+	// reading the block.
+	if block := c.readBlock(height); block != nil {
+		err := c.ldb.Delete(hashKey(block.Hash), &opt.WriteOptions{Sync: false})
+		if err != nil {
+			Log.Warning("error flushing block hash at height: ", height, " ", err)
+		}
+		c.flushBlockResults(block.Hash)
+		batch := new(leveldb.Batch)
+		batch.Delete(headerKey(block.Hash))
+		for _, tx := range block.Vtx {
+			for i := range tx.Vout {
+				batch.Delete(prevoutKey(tx.Hash, uint32(i)))
 			}
 		}
+		if err := c.ldb.Write(batch, &opt.WriteOptions{Sync: false}); err != nil {
+			Log.Warning("error flushing header and outputs at height: ", height, " ", err)
+		}
+	}
+	// Likewise the txid index entries, using the block's txid list.
+	if txids := c.readTxids(height); txids != nil {
+		batch := new(leveldb.Batch)
+		for _, txid := range txids {
+			batch.Delete(txidKey(txid))
+		}
+		batch.Delete([]byte(blockTxidsPrefix + strconv.Itoa(height)))
+		err := c.ldb.Write(batch, &opt.WriteOptions{Sync: false})
+		if err != nil {
+			Log.Warning("error flushing txids at height: ", height, " ", err)
+		}
+	}
+	key := []byte(blockHeightPrefix + strconv.Itoa(height))
+	err := c.ldb.Delete(key, &opt.WriteOptions{Sync: false})
+	if err != nil {
+		Log.Warning("error flushing block at height: ", height, " ", err)
+	}
+}
+
+// flushBlockResults removes the stored rpc results as of the block with the
+// given hash (or all of them, if hash is nil).
+func (c *BlockCache) flushBlockResults(hash []byte) {
+	batch := new(leveldb.Batch)
+	iter := c.ldb.NewIterator(util.BytesPrefix(blockResultKey(hash, "")), nil)
+	for iter.Next() {
+		batch.Delete(append([]byte{}, iter.Key()...))
+	}
+	iter.Release()
+	if batch.Len() == 0 {
+		return
+	}
+	if err := c.ldb.Write(batch, &opt.WriteOptions{Sync: false}); err != nil {
+		Log.Warning("error flushing rpc results: ", err)
 	}
 }
+
 func (c *BlockCache) storeNewHeight(sync bool) error {
 	bytesHeight := make([]byte, 8)
 	binary.LittleEndian.PutUint64(bytesHeight, (uint64)(c.nextBlock&0xFFFFFFFFFFFFFFF))
 	return c.ldb.Put([]byte(idPrefix+c.verusID), bytesHeight, &opt.WriteOptions{Sync: sync})
 }
 
-func (c *BlockCache) storeNewBlock(height int, block []byte) error {
+func (c *BlockCache) storeNewBlock(height int, hash []byte, block []byte) error {
 	err := c.ldb.Put([]byte(blockHeightPrefix+strconv.Itoa(height)), block, &opt.WriteOptions{Sync: false})
 	if err != nil {
-		Log.Fatal("blocks write at height", height, "failed: ", err)
 		return err
 	}
-	var hashID []byte = nil
-	copy(hashID, blockHashPrefix)
-	hashID = append(hashID, []byte(c.latestHash)...)
-	err = c.ldb.Put(hashID, block, &opt.WriteOptions{Sync: false})
+	bytesHeight := make([]byte, 8)
+	binary.LittleEndian.PutUint64(bytesHeight, uint64(height))
+	err = c.ldb.Put(hashKey(hash), bytesHeight, &opt.WriteOptions{Sync: false})
 	if err != nil {
-		Log.Fatal("hash write at height", height, "failed: ", err)
 		return err
 	}
 	return nil
 }
+
+// storeNewTxids records the block's txids, and the location of each of them.
+func (c *BlockCache) storeNewTxids(height int, txids [][]byte) error {
+	batch := new(leveldb.Batch)
+	list := make([]byte, 0, 32*len(txids))
+	for i, txid := range txids {
+		list = append(list, txid...)
+		location := make([]byte, 16)
+		binary.LittleEndian.PutUint64(location[:8], uint64(height))
+		binary.LittleEndian.PutUint64(location[8:], uint64(i))
+		batch.Put(txidKey(txid), location)
+	}
+	batch.Put([]byte(blockTxidsPrefix+strconv.Itoa(height)), list)
+	return c.ldb.Write(batch, &opt.WriteOptions{Sync: false})
+}
+
+// storeNewOutputs records the value of each of the block's transparent
+// outputs, so the fees of the transactions that spend them can be computed.
+func (c *BlockCache) storeNewOutputs(height int, block *walletrpc.CompactBlock) error {
+	batch := new(leveldb.Batch)
+	for _, tx := range block.Vtx {
+		for i, out := range tx.Vout {
+			value := make([]byte, 16)
+			binary.LittleEndian.PutUint64(value[:8], out.Value)
+			binary.LittleEndian.PutUint64(value[8:], uint64(height))
+			batch.Put(prevoutKey(tx.Hash, uint32(i)), value)
+		}
+	}
+	return c.ldb.Write(batch, &opt.WriteOptions{Sync: false})
+}
+
+// GetPrevoutValue returns the value of the given transparent output (by txid,
+// little-endian wire order, and index), if its transaction is in a cached
+// block. It is a parser.PrevoutValue.
+func (c *BlockCache) GetPrevoutValue(txid []byte, index uint32) (uint64, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if c.ldb == nil {
+		return 0, false
+	}
+	data, err := c.ldb.Get(prevoutKey(txid, index), nil)
+	if err != nil || len(data) != 16 {
+		return 0, false
+	}
+	height := int(binary.LittleEndian.Uint64(data[8:]))
+	if height < c.firstBlock || height >= c.nextBlock {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(data[:8]), true
+}
+
+// prevoutKey returns the db key of the output value index entry for the
+// given output.
+func prevoutKey(txid []byte, index uint32) []byte {
+	key := make([]byte, 0, len(prevoutPrefix)+len(txid)+4)
+	key = append(key, prevoutPrefix...)
+	key = append(key, txid...)
+	return binary.LittleEndian.AppendUint32(key, index)
+}
+
+// txidKey returns the db key of the txid index entry for the given
+// transaction ID (little-endian wire order).
+func txidKey(txid []byte) []byte {
+	key := make([]byte, 0, len(txidPrefix)+len(txid))
+	key = append(key, txidPrefix...)
+	return append(key, txid...)
+}
+
+// blockResultKey returns the db key of the stored result of the given rpc
+// request as of the block with the given hash (little-endian wire order).
+func blockResultKey(hash []byte, request string) []byte {
+	key := make([]byte, 0, len(blockResultPrefix)+len(hash)+len(request))
+	key = append(key, blockResultPrefix...)
+	key = append(key, hash...)
+	return append(key, request...)
+}
+
+// headerKey returns the db key of the header of the block with the given
+// hash (little-endian wire order).
+func headerKey(hash []byte) []byte {
+	key := make([]byte, 0, len(blockHeaderPrefix)+len(hash))
+	key = append(key, blockHeaderPrefix...)
+	return append(key, hash...)
+}
+
+// hashKey returns the db key of the hash index entry for the given block
+// hash (little-endian wire order).
+func hashKey(hash []byte) []byte {
+	key := make([]byte, 0, len(blockHashPrefix)+len(hash))
+	key = append(key, blockHashPrefix...)
+	return append(key, hash...)
+}
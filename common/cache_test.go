@@ -4,17 +4,21 @@
 package common
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
 
 	"github.com/asherda/lightwalletd/parser"
 	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/syndtr/goleveldb/leveldb"
 )
 
 var compacts []*walletrpc.CompactBlock
+var txids [][][]byte // txids of each of the compacts' full blocks
 var cache *BlockCache
 
 const (
@@ -22,6 +26,16 @@ const (
 	unitTestChain = "unittestnet"
 )
 
+// openTestCache opens the unit test leveldb and creates a cache on it.
+func openTestCache(startHeight int, redownload bool) *BlockCache {
+	db, err := leveldb.OpenFile(unitTestPath, nil)
+	if err != nil {
+		os.Stderr.WriteString(fmt.Sprintf("Cannot open %s: %v", unitTestPath, err))
+		os.Exit(1)
+	}
+	return NewBlockCache(db, unitTestChain, startHeight, redownload)
+}
+
 func TestCache(t *testing.T) {
 	type compactTest struct {
 		BlockHeight int    `json:"block"`
@@ -54,11 +68,12 @@ func TestCache(t *testing.T) {
 			t.Error("Extra data remaining")
 		}
 		compacts = append(compacts, block.ToCompact())
+		txids = append(txids, blockTxids(block))
 	}
 
 	// Pretend Sapling starts at 289460.
 	os.RemoveAll(unitTestPath)
-	cache = NewBlockCache(unitTestPath, unitTestChain, 289460, true)
+	cache = openTestCache(289460, true)
 
 	// Initially cache is empty.
 	if cache.GetLatestHeight() != -1 {
@@ -73,15 +88,22 @@ func TestCache(t *testing.T) {
 	fillCache(t)
 	reorgCache(t)
 	fillCache(t)
+	checkHashIndex(t, 6)
+	checkTxIndex(t, 6)
 
 	// Simulate a restart to ensure the db files are read correctly.
-	cache = NewBlockCache(unitTestPath, unitTestChain, 289460, false)
+	cache.Close()
+	cache = openTestCache(289460, false)
 
 	// Should still be 6 blocks.
 	if cache.nextBlock != 289466 {
 		t.Fatal("unexpected nextBlock height")
 	}
+	checkHashIndex(t, 6)
+	checkTxIndex(t, 6)
 	reorgCache(t)
+	checkHashIndex(t, 3)
+	checkTxIndex(t, 3)
 
 	// Reorg to before the first block moves back to only the first block
 	cache.Reorg(289459)
@@ -91,17 +113,134 @@ func TestCache(t *testing.T) {
 	if cache.nextBlock != 289460 {
 		t.Fatal("unexpected nextBlock: ", cache.nextBlock)
 	}
+	checkHashIndex(t, 0)
+	checkTxIndex(t, 0)
 
 	// Clean up the test files.
 	cache.Close()
 	os.RemoveAll(unitTestPath)
 }
 
+func TestCacheOldFormat(t *testing.T) {
+	os.RemoveAll(unitTestPath)
+	defer os.RemoveAll(unitTestPath)
+	oldCache := openTestCache(380640, false)
+	for i := 0; i < 2; i++ {
+		block := parseTestBlock(t, i).ToCompact()
+		if i == 1 {
+			// As stored by an older lightwalletd
+			block.ProtoVersion = 0
+		}
+		if err := oldCache.Add(380640+i, block); err != nil {
+			t.Fatal("cache.Add failed:", err)
+		}
+	}
+	oldCache.Close()
+
+	// Blocks in an older format are redownloaded.
+	oldCache = openTestCache(380640, false)
+	defer oldCache.Close()
+	if oldCache.GetNextHeight() != 380640 || oldCache.Get(380640) != nil {
+		t.Fatal("cache in an older format wasn't flushed ", oldCache.GetNextHeight())
+	}
+}
+
+func TestPrevoutValues(t *testing.T) {
+	os.RemoveAll(unitTestPath)
+	defer os.RemoveAll(unitTestPath)
+	prevoutCache := openTestCache(380640, false)
+	defer prevoutCache.Close()
+	block := parseTestBlock(t, 0).ToCompact()
+	if err := prevoutCache.Add(380640, block); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+	outputs := 0
+	for _, tx := range block.Vtx {
+		for i, out := range tx.Vout {
+			value, ok := prevoutCache.GetPrevoutValue(tx.Hash, uint32(i))
+			if !ok || value != out.Value {
+				t.Fatal("unexpected prevout value ", value, ok, " expecting ", out.Value)
+			}
+			outputs++
+		}
+		if _, ok := prevoutCache.GetPrevoutValue(tx.Hash, uint32(len(tx.Vout))); ok {
+			t.Fatal("unexpected prevout value, no such output")
+		}
+	}
+	if outputs == 0 {
+		t.Fatal("test block has no transparent outputs, test broken?")
+	}
+
+	// The values of a reorged block's outputs are no longer known.
+	prevoutCache.Reorg(380640)
+	if _, ok := prevoutCache.GetPrevoutValue(block.Vtx[0].Hash, 0); ok {
+		t.Fatal("unexpected prevout value after reorg")
+	}
+}
+
+// Reorg(height) discards the block at that height, too: the new chain's
+// block at that height is the next one added.
+func TestCacheReorgBoundary(t *testing.T) {
+	os.RemoveAll(unitTestPath)
+	defer os.RemoveAll(unitTestPath)
+	reorgTestCache := openTestCache(380640, false)
+	var discarded *walletrpc.CompactBlock
+	var discardedTxids [][]byte
+	for i := 0; i < 3; i++ {
+		block := parseTestBlock(t, i)
+		var blockTxids [][]byte
+		for _, tx := range block.Transactions() {
+			blockTxids = append(blockTxids, tx.GetEncodableHash())
+		}
+		compact := block.ToCompact()
+		header, err := block.MarshalHeader()
+		if err != nil {
+			t.Fatal("MarshalHeader failed:", err)
+		}
+		if err := reorgTestCache.AddWithTxids(380640+i, compact, blockTxids, header); err != nil {
+			t.Fatal("cache.AddWithTxids failed:", err)
+		}
+		if i == 1 {
+			discarded, discardedTxids = compact, blockTxids
+		}
+	}
+	reorgTestCache.Reorg(380641)
+	if reorgTestCache.GetNextHeight() != 380641 || reorgTestCache.Get(380640) == nil ||
+		!bytes.Equal(reorgTestCache.GetLatestHash(), reorgTestCache.Get(380640).Hash) {
+		t.Fatal("unexpected cache after reorg, next height ", reorgTestCache.GetNextHeight())
+	}
+	// The discarded block, and everything indexed from it, is gone.
+	if reorgTestCache.readBlock(380641) != nil {
+		t.Fatal("discarded block still stored")
+	}
+	if _, err := reorgTestCache.ldb.Get(hashKey(discarded.Hash), nil); err == nil {
+		t.Fatal("discarded block's hash still indexed")
+	}
+	if reorgTestCache.GetHeader(discarded.Hash) != nil {
+		t.Fatal("discarded block's header still stored")
+	}
+	if reorgTestCache.GetHeader(reorgTestCache.Get(380640).Hash) == nil {
+		t.Fatal("kept block's header missing")
+	}
+	for _, txid := range discardedTxids {
+		if height, _ := reorgTestCache.GetTxLocation(txid); height != -1 {
+			t.Fatal("discarded block's transaction still indexed")
+		}
+	}
+	// That's also the height a restart resumes from.
+	reorgTestCache.Close()
+	reorgTestCache = openTestCache(380640, false)
+	defer reorgTestCache.Close()
+	if reorgTestCache.GetNextHeight() != 380641 {
+		t.Fatal("unexpected next height after reopening ", reorgTestCache.GetNextHeight())
+	}
+}
+
 func reorgCache(t *testing.T) {
 	// Simulate a reorg by adding a block whose height is lower than the latest;
 	// we're replacing the second block, so there should be only two blocks.
 	cache.Reorg(289461)
-	err := cache.Add(289461, compacts[1])
+	err := cache.AddWithTxids(289461, compacts[1], txids[1], nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -111,9 +250,6 @@ func reorgCache(t *testing.T) {
 	if cache.nextBlock != 289462 {
 		t.Fatal("unexpected nextBlock height")
 	}
-	if len(cache.starts) != 3 {
-		t.Fatal("unexpected len(cache.starts)")
-	}
 
 	// some "black-box" tests (using exported interfaces)
 	if cache.GetLatestHeight() != 289461 {
@@ -124,7 +260,7 @@ func reorgCache(t *testing.T) {
 	}
 
 	// Make sure we can go forward from here
-	err = cache.Add(289462, compacts[2])
+	err = cache.AddWithTxids(289462, compacts[2], txids[2], nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -134,9 +270,6 @@ func reorgCache(t *testing.T) {
 	if cache.nextBlock != 289463 {
 		t.Fatal("unexpected nextBlock height")
 	}
-	if len(cache.starts) != 4 {
-		t.Fatal("unexpected len(cache.starts)")
-	}
 
 	if cache.GetLatestHeight() != 289462 {
 		t.Fatal("unexpected GetLatestHeight")
@@ -152,7 +285,7 @@ func fillCache(t *testing.T) {
 	next := 289460
 	cache.Reorg(next)
 	for i, compact := range compacts {
-		err := cache.Add(next, compact)
+		err := cache.AddWithTxids(next, compact, txids[i], nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -165,9 +298,6 @@ func fillCache(t *testing.T) {
 		if cache.nextBlock != 289460+i+1 {
 			t.Fatal("unexpected nextBlock height")
 		}
-		if len(cache.starts) != i+2 {
-			t.Fatal("unexpected len(cache.starts)")
-		}
 
 		// some "black-box" tests (using exported interfaces)
 		if cache.GetLatestHeight() != 289460+i {
@@ -182,3 +312,52 @@ func fillCache(t *testing.T) {
 		}
 	}
 }
+
+// The first n test blocks should be found by hash, and the rest not.
+func checkHashIndex(t *testing.T, n int) {
+	for i, compact := range compacts {
+		height := cache.GetHeightByHash(compact.Hash)
+		b := cache.GetByHash(compact.Hash)
+		if i >= n {
+			if height != -1 || b != nil {
+				t.Fatal("unexpected block found by hash, height ", 289460+i)
+			}
+			continue
+		}
+		if height != 289460+i {
+			t.Fatal("unexpected GetHeightByHash: ", height, " expecting: ", 289460+i)
+		}
+		if b == nil {
+			t.Fatal("unexpected GetByHash failure, height ", 289460+i)
+		}
+		if int(b.Height) != 289460+i || !bytes.Equal(b.Hash, compact.Hash) {
+			t.Fatal("unexpected block contents from GetByHash")
+		}
+	}
+	if cache.GetByHash(make([]byte, 32)) != nil {
+		t.Fatal("unexpected GetByHash success, unknown hash")
+	}
+}
+
+// The transactions of the first n test blocks should be found by txid,
+// and the rest not.
+func checkTxIndex(t *testing.T, n int) {
+	for i := range compacts {
+		for j, txid := range txids[i] {
+			height, index := cache.GetTxLocation(txid)
+			if i >= n {
+				if height != -1 || index != -1 {
+					t.Fatal("unexpected transaction found by txid, height ", 289460+i)
+				}
+				continue
+			}
+			if height != 289460+i || index != j {
+				t.Fatal("unexpected GetTxLocation: ", height, " ", index,
+					" expecting: ", 289460+i, " ", j)
+			}
+		}
+	}
+	if height, _ := cache.GetTxLocation(make([]byte, 32)); height != -1 {
+		t.Fatal("unexpected GetTxLocation success, unknown txid")
+	}
+}
@@ -5,10 +5,12 @@
 package common
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/asherda/lightwalletd/parser"
@@ -39,6 +41,8 @@ type Options struct {
 	RPCPassword         string `json:"rpcpassword"`
 	RPCHost             string `json:"rpchost"`
 	RPCPort             string `json:"rpcport"`
+	ZMQHashBlockAddr    string `json:"zmq_hashblock_address,omitempty"`
+	ZMQRawTxAddr        string `json:"zmq_rawtx_address,omitempty"`
 	NoTLSVeryInsecure   bool   `json:"no_tls_very_insecure,omitempty"`
 	GenCertVeryInsecure bool   `json:"gen_cert_very_insecure,omitempty"`
 	Redownload          bool   `json:"redownload"`
@@ -46,6 +50,11 @@ type Options struct {
 	PingEnable          bool   `json:"ping_enable"`
 	Darkside            bool   `json:"darkside"`
 	DarksideTimeout     uint64 `json:"darkside_timeout"`
+	SyncWorkers         int    `json:"sync_workers,omitempty"`
+	MaxOutage           uint64 `json:"max_outage,omitempty"`
+	MaxSyncLag          int    `json:"max_sync_lag,omitempty"`
+	ValidateBlocks      bool   `json:"validate_blocks,omitempty"`
+	CompactBlockHeaders bool   `json:"compact_block_headers,omitempty"`
 }
 
 // RawRequest points to the function to send a an RPC request to zcashd;
@@ -62,6 +71,7 @@ var RawRequest func(method string, params []json.RawMessage) (json.RawMessage, e
 var Time struct {
 	Sleep func(d time.Duration)
 	Now   func() time.Time
+	After func(d time.Duration) <-chan time.Time
 }
 
 // Log as a global variable simplifies logging
@@ -128,7 +138,8 @@ type (
 		Addresses []string `json:"addresses"`
 	}
 	ZcashdRpcReplyGetaddressbalance struct {
-		Balance int64
+		Balance         int64
+		CurrencyBalance map[string]json.Number // currency i-address to amount (in coins)
 	}
 
 	// zcashd rpc "getaddressutxos"
@@ -142,6 +153,127 @@ type (
 		Script      string
 		Satoshis    uint64
 		Height      int
+		// currency i-address to amount (in coins)
+		CurrencyValues map[string]json.Number `json:",omitempty"`
+	}
+
+	// verusd rpc "getidentity"
+	ZcashdRpcIdentity struct {
+		Version             uint32
+		Flags               uint32
+		PrimaryAddresses    []string
+		MinimumSignatures   int32
+		Name                string
+		IdentityAddress     string
+		Parent              string
+		SystemID            string
+		ContentMap          map[string]string
+		RevocationAuthority string
+		RecoveryAuthority   string
+		PrivateAddress      string
+		TimeLock            uint64
+	}
+	ZcashdRpcReplyGetidentity struct {
+		FullyQualifiedName string
+		Identity           ZcashdRpcIdentity
+		Status             string
+		CanSpendFor        bool
+		CanSignFor         bool
+		BlockHeight        int
+		Txid               string
+		Vout               uint32
+	}
+
+	// verusd rpc "getidentityhistory"
+	ZcashdRpcIdentityHistoryEntry struct {
+		Identity  ZcashdRpcIdentity
+		BlockHash string
+		Height    int
+		Output    struct {
+			Txid    string
+			VoutNum uint32
+		}
+	}
+	ZcashdRpcReplyGetidentityhistory struct {
+		FullyQualifiedName string
+		Status             string
+		History            []ZcashdRpcIdentityHistoryEntry
+	}
+
+	// verusd rpc "getcurrency"; amounts are in coins
+	ZcashdRpcReserveCurrency struct {
+		CurrencyID     string
+		Weight         json.Number
+		Reserves       json.Number
+		PriceInReserve json.Number
+	}
+	ZcashdRpcCurrencyState struct {
+		Flags             uint32
+		CurrencyID        string
+		Supply            json.Number
+		InitialSupply     json.Number
+		Emitted           json.Number
+		ReserveCurrencies []ZcashdRpcReserveCurrency
+	}
+	ZcashdRpcReplyGetcurrency struct {
+		Version              uint32
+		Options              uint32
+		Name                 string
+		FullyQualifiedName   string
+		CurrencyID           string
+		Parent               string
+		SystemID             string
+		LaunchSystemID       string
+		NotarizationProtocol int32
+		ProofProtocol        int32
+		StartBlock           uint64
+		EndBlock             uint64
+		Currencies           []string
+		Weights              []json.Number
+		Conversions          []json.Number
+		InitialSupply        json.Number
+		IDRegistrationFees   json.Number
+		IDReferralLevels     int32
+		IDImportFees         json.Number
+		Notaries             []string
+		MinNotariesConfirm   int32
+		BestHeight           uint64
+		BestCurrencyState    *ZcashdRpcCurrencyState `json:",omitempty"`
+	}
+
+	// verusd rpc "listcurrencies"
+	ZcashdRpcRequestListcurrencies struct {
+		LaunchState string `json:"launchstate,omitempty"`
+		SystemType  string `json:"systemtype,omitempty"`
+		FromSystem  string `json:"fromsystem,omitempty"`
+	}
+	ZcashdRpcListcurrenciesEntry struct {
+		CurrencyDefinition ZcashdRpcReplyGetcurrency
+		BestHeight         uint64
+		BestCurrencyState  *ZcashdRpcCurrencyState `json:",omitempty"`
+	}
+	ZcashdRpcReplyListcurrencies []ZcashdRpcListcurrenciesEntry
+
+	// verusd rpc "getcurrencystate"
+	ZcashdRpcCurrencyStateEntry struct {
+		Height        uint64
+		BlockTime     uint32
+		CurrencyState ZcashdRpcCurrencyState
+	}
+	ZcashdRpcReplyGetcurrencystate []ZcashdRpcCurrencyStateEntry
+
+	// verusd rpc "estimateconversion"
+	ZcashdRpcRequestEstimateconversion struct {
+		Currency   string      `json:"currency"`
+		ConvertTo  string      `json:"convertto"`
+		Via        string      `json:"via,omitempty"`
+		Amount     json.Number `json:"amount"`
+		PreConvert bool        `json:"preconvert,omitempty"`
+	}
+	ZcashdRpcReplyEstimateconversion struct {
+		EstimatedCurrencyOut   json.Number
+		NetInputAmount         json.Number
+		EstimatedCurrencyState *ZcashdRpcCurrencyState `json:",omitempty"`
 	}
 )
 
@@ -177,6 +309,9 @@ func FirstRPC() {
 }
 
 func GetLightdInfo() (*walletrpc.LightdInfo, error) {
+	if err := NodeUnavailable(); err != nil {
+		return nil, err
+	}
 	result, rpcErr := RawRequest("getinfo", []json.RawMessage{})
 	if rpcErr != nil {
 		return nil, rpcErr
@@ -225,19 +360,78 @@ func GetLightdInfo() (*walletrpc.LightdInfo, error) {
 }
 
 func getBlockFromRPC(height int) (*walletrpc.CompactBlock, error) {
+	block, err := getFullBlockFromRPC(height)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return toCompactWithHeader(block)
+}
+
+// getFullBlockFromRPC is like getBlockFromRPC, but returns the full
+// (parsed) block rather than its compact form.
+func getFullBlockFromRPC(height int) (*parser.Block, error) {
+	block, err := getFullBlockFromRPCByID(strconv.Itoa(height))
+	if err != nil || block == nil {
+		return nil, err
+	}
+	if block.GetHeight() != height {
+		return nil, errors.New("received unexpected height block")
+	}
+	return block, nil
+}
+
+// getBlockByHashFromRPC asks zcashd for the block with the given hash
+// (little-endian wire order). It returns nil (and no error) if zcashd
+// doesn't know about the block.
+func getBlockByHashFromRPC(hash []byte) (*walletrpc.CompactBlock, error) {
+	block, err := getBlockFromRPCByID(hex.EncodeToString(parser.Reverse(hash)))
+	if err != nil || block == nil {
+		return nil, err
+	}
+	if !bytes.Equal(block.Hash, hash) {
+		return nil, errors.New("received unexpected hash block")
+	}
+	return block, nil
+}
+
+// getBlockFromRPCByID issues the zcashd getblock rpc; the id may be either
+// a height or a (big-endian, hex) block hash, zcashd accepts either.
+func getBlockFromRPCByID(id string) (*walletrpc.CompactBlock, error) {
+	block, err := getFullBlockFromRPCByID(id)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return toCompactWithHeader(block)
+}
+
+// toCompactWithHeader returns the compact form of a block that isn't cached,
+// including its header (which, for a cached block, is stored separately).
+func toCompactWithHeader(block *parser.Block) (*walletrpc.CompactBlock, error) {
+	header, err := block.MarshalHeader()
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing block header")
+	}
+	compact := block.ToCompact()
+	compact.Header = header
+	return compact, nil
+}
+
+func getFullBlockFromRPCByID(id string) (*parser.Block, error) {
 	params := make([]json.RawMessage, 2)
-	heightJSON, err := json.Marshal(strconv.Itoa(height))
+	idJSON, err := json.Marshal(id)
 	if err != nil {
-		Log.Fatal("getBlockFromRPC bad height argument", height, err)
+		Log.Fatal("getBlockFromRPC bad block id argument", id, err)
 	}
-	params[0] = heightJSON
+	params[0] = idJSON
 	params[1] = json.RawMessage("0") // non-verbose (raw hex)
 	result, rpcErr := RawRequest("getblock", params)
 
 	// For some reason, the error responses are not JSON
 	if rpcErr != nil {
-		// Check to see if we are requesting a height the zcashd doesn't have yet
-		if (strings.Split(rpcErr.Error(), ":"))[0] == "-8" {
+		// Check to see if we are requesting a height the zcashd doesn't have yet,
+		// or a hash it has never seen
+		errCode := (strings.Split(rpcErr.Error(), ":"))[0]
+		if errCode == "-8" || errCode == "-5" {
 			return nil, nil
 		}
 		return nil, errors.Wrap(rpcErr, "error requesting block")
@@ -262,19 +456,33 @@ func getBlockFromRPC(height int) (*walletrpc.CompactBlock, error) {
 	if len(rest) != 0 {
 		return nil, errors.New("received overlong message")
 	}
-
-	if block.GetHeight() != height {
-		return nil, errors.New("received unexpected height block")
+	if block.GetHeight() < 0 {
+		return nil, errors.New("received block with no height")
 	}
 
-	return block.ToCompact(), nil
+	return block, nil
 }
 
+// SyncWorkers is the number of blocks BlockIngestor requests from zcashd
+// concurrently while it's far behind the tip (as during the initial sync,
+// or after --redownload). Values less than 2 mean one block at a time.
+var SyncWorkers int
+
+// pipelineTipDistance is how close to zcashd's tip BlockIngestor fetches
+// blocks concurrently; from there on, it polls for one block at a time.
+const pipelineTipDistance = 10
+
 var (
 	ingestorRunning  bool
 	stopIngestorChan = make(chan struct{})
+	// ingestorStopped is closed when the BlockIngestor that's running
+	// returns. It's set before that BlockIngestor accepts a stop request.
+	ingestorStopped chan struct{}
 )
 
+// errIngestorStopped is returned by ingestPipelined if it was asked to stop.
+var errIngestorStopped = errors.New("block ingestor stopped")
+
 func startIngestor(c *BlockCache) {
 	if !ingestorRunning {
 		ingestorRunning = true
@@ -285,15 +493,75 @@ func stopIngestor() {
 	if ingestorRunning {
 		ingestorRunning = false
 		stopIngestorChan <- struct{}{}
+		<-ingestorStopped
+	}
+}
+
+// StopBlockIngestor asks BlockIngestor to stop, once it's done with the block
+// it's working on, and waits up to the given time for it to return. It returns
+// false if the ingestor is still busy (waiting for zcashd, for example), in
+// which case it may still be using the cache.
+func StopBlockIngestor(timeout time.Duration) bool {
+	expired := time.After(timeout)
+	select {
+	case stopIngestorChan <- struct{}{}:
+	case <-expired:
+		return false
+	}
+	select {
+	case <-ingestorStopped:
+		return true
+	case <-expired:
+		return false
 	}
 }
 
 // BlockIngestor runs as a goroutine and polls zcashd for new blocks, adding them
 // to the cache. The repetition count, rep, is nonzero only for unit-testing.
 func BlockIngestor(c *BlockCache, rep int) {
+	stopped := make(chan struct{})
+	ingestorStopped = stopped
+	defer close(stopped)
+
 	lastLog := Time.Now()
 	lastHeightLogged := 0
 
+	// Any error talking to zcashd (or storing what it returns) is retried,
+	// after a backoff, rather than being fatal. It returns false if, instead,
+	// the ingestor is asked to stop during the backoff.
+	failures := 0
+	retry := func(err error) bool {
+		failures++
+		outage := setNodeError(err)
+		if MaxNodeOutage > 0 && outage >= MaxNodeOutage {
+			Log.WithFields(logrus.Fields{
+				"error": err,
+			}).Fatal("zcashd has been unavailable for ", outage, ", giving up")
+		}
+		delay := retryDelay(failures)
+		Log.WithFields(logrus.Fields{
+			"error": err,
+			"retry": failures,
+		}).Warn("error with zcashd, retrying in ", delay)
+		select {
+		case <-Time.After(delay):
+			return true
+		case <-stopIngestorChan:
+			return false
+		}
+	}
+	succeeded := func() {
+		failures = 0
+		setNodeHealthy()
+	}
+	// A block that fails validation isn't zcashd being unavailable: it's
+	// not retried (and doesn't count toward the outage) but replaced, by
+	// zcashd, with a block that passes, so wait for one.
+	rejected := func(height int) bool {
+		Log.Info("Waiting for a block to replace rejected block ", height)
+		return waitForBlock()
+	}
+
 	// Start listening for new blocks
 	for i := 0; rep == 0 || i < rep; i++ {
 		// stop if requested
@@ -305,41 +573,104 @@ func BlockIngestor(c *BlockCache, rep int) {
 
 		result, err := RawRequest("getbestblockhash", []json.RawMessage{})
 		if err != nil {
-			Log.WithFields(logrus.Fields{
-				"error": err,
-			}).Fatal("error zcashd getbestblockhash rpc")
+			if !retry(errors.Wrap(err, "error zcashd getbestblockhash rpc")) {
+				return
+			}
+			continue
 		}
 		var hashHex string
 		err = json.Unmarshal(result, &hashHex)
 		if err != nil {
-			Log.Fatal("bad getbestblockhash return:", err, result)
+			if !retry(errors.Wrap(err, "bad getbestblockhash return")) {
+				return
+			}
+			continue
 		}
 		lastBestBlockHash := []byte{}
 		lastBestBlockHash, err = hex.DecodeString(hashHex)
 		if err != nil {
-			Log.Fatal("error decoding getbestblockhash", err, hashHex)
+			if !retry(errors.Wrap(err, "error decoding getbestblockhash")) {
+				return
+			}
+			continue
 		}
 
 		height := c.GetNextHeight()
 		if string(lastBestBlockHash) == string(parser.Reverse(c.GetLatestHash())) {
 			// Synced
+			succeeded()
 			c.Sync()
 			if lastHeightLogged != height-1 {
 				lastHeightLogged = height - 1
 				Log.Info("Waiting for block: ", height)
 			}
-			Time.Sleep(2 * time.Second)
+			if !waitForBlock() {
+				return
+			}
 			lastLog = Time.Now()
 			continue
 		}
-		var block *walletrpc.CompactBlock
-		block, err = getBlockFromRPC(height)
+		if SyncWorkers > 1 && !DarksideEnabled {
+			tip, err := getBlockCount()
+			if err != nil {
+				if !retry(errors.Wrap(err, "error zcashd getblockcount rpc")) {
+					return
+				}
+				continue
+			}
+			if tip-height > pipelineTipDistance {
+				added, rejectedBlock, err := ingestPipelined(c, height, tip-pipelineTipDistance, SyncWorkers)
+				if err == errIngestorStopped {
+					return
+				}
+				if err != nil {
+					if !retry(errors.Wrapf(err, "getblock %d failed", height+added)) {
+						return
+					}
+					continue
+				}
+				lastLog = Time.Now()
+				if added > 0 {
+					succeeded()
+				}
+				if rejectedBlock {
+					if !rejected(height + added) {
+						return
+					}
+					continue
+				}
+				if added > 0 {
+					continue
+				}
+				// The first block doesn't extend the cache; handle that
+				// (probably a reorg) one block at a time, below.
+			}
+		}
+		var fullBlock *parser.Block
+		fullBlock, err = getFullBlockFromRPC(height)
 		if err != nil {
-			Log.Fatal("getblock ", height, " failed, will retry: ", err)
+			if !retry(errors.Wrapf(err, "getblock %d failed", height)) {
+				return
+			}
+			continue
+		}
+		if fullBlock != nil && validateBlock(c, height, fullBlock) != nil {
+			if !rejected(height) {
+				return
+			}
+			continue
+		}
+		succeeded()
+		var block *walletrpc.CompactBlock
+		if fullBlock != nil {
+			block = fullBlock.ToCompactWithFees(c.GetPrevoutValue)
 		}
 		if block != nil && c.HashMatch(block.PrevHash) {
-			if err = c.Add(height, block); err != nil {
-				Log.Fatal("Cache add failed:", err)
+			if err = addBlock(c, height, fullBlock, block); err != nil {
+				if !retry(errors.Wrap(err, "cache add failed")) {
+					return
+				}
+				continue
 			}
 			// Don't log these too often.
 			if DarksideEnabled || Time.Now().Sub(lastLog).Seconds() >= 4 {
@@ -360,6 +691,124 @@ func BlockIngestor(c *BlockCache, rep int) {
 	}
 }
 
+// ingestPipelined adds the blocks at heights [start, end] to the cache,
+// fetching them from zcashd with the given number of concurrent workers.
+// It stops early, without error, at the first block that doesn't extend
+// the cache (leaving the reorg to the caller) or that's rejected (reporting
+// that), and returns the number of blocks added. If the ingestor is asked to
+// stop, it returns errIngestorStopped.
+func ingestPipelined(c *BlockCache, start, end, workers int) (added int, rejected bool, err error) {
+	done := make(chan struct{})
+	results := fetchBlocks(start, end, workers, done)
+	defer func() {
+		// Wait for requests still in progress.
+		close(done)
+		for range results {
+		}
+	}()
+
+	lastLog := Time.Now()
+	for result := range results {
+		var r fetchResult
+		select {
+		case r = <-result:
+		case <-stopIngestorChan:
+			return added, false, errIngestorStopped
+		}
+		if r.err != nil {
+			return added, false, r.err
+		}
+		if r.block == nil {
+			// zcashd's tip has moved back
+			return added, false, nil
+		}
+		height := start + added
+		if validateBlock(c, height, r.block) != nil {
+			return added, true, nil
+		}
+		block := r.block.ToCompactWithFees(c.GetPrevoutValue)
+		if !c.HashMatch(block.PrevHash) {
+			return added, false, nil
+		}
+		if err := addBlock(c, height, r.block, block); err != nil {
+			return added, false, errors.Wrap(err, "cache add failed")
+		}
+		added++
+		// Don't log these too often.
+		if Time.Now().Sub(lastLog).Seconds() >= 4 {
+			lastLog = Time.Now()
+			Log.Info("Adding block to cache ", height, " ", displayHash(block.Hash))
+		}
+	}
+	return added, false, nil
+}
+
+// fetchResult is the outcome of one block request made by fetchBlocks.
+type fetchResult struct {
+	block *parser.Block
+	err   error
+}
+
+// fetchBlocks requests the blocks at heights [start, end] from zcashd using
+// the given number of concurrent workers. It returns a channel that delivers,
+// in height order, a channel on which each block's result will arrive. At most
+// 2*workers blocks are fetched ahead of the consumer. Closing done stops the
+// requests; the returned channel is closed once none are in progress.
+func fetchBlocks(start, end, workers int, done <-chan struct{}) <-chan chan fetchResult {
+	type job struct {
+		height int
+		result chan<- fetchResult
+	}
+	jobs := make(chan job)
+	results := make(chan chan fetchResult, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				block, err := getFullBlockFromRPC(j.height)
+				j.result <- fetchResult{block: block, err: err}
+			}
+		}()
+	}
+	go func() {
+		defer func() {
+			close(jobs)
+			wg.Wait()
+			close(results)
+		}()
+		for height := start; height <= end; height++ {
+			result := make(chan fetchResult, 1)
+			select {
+			case results <- result:
+			case <-done:
+				return
+			}
+			select {
+			case jobs <- job{height: height, result: result}:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return results
+}
+
+// getBlockCount returns the height of zcashd's best chain tip.
+func getBlockCount() (int, error) {
+	result, rpcErr := RawRequest("getblockcount", []json.RawMessage{})
+	if rpcErr != nil {
+		return 0, rpcErr
+	}
+	var count int
+	err := json.Unmarshal(result, &count)
+	if err != nil {
+		return 0, errors.Wrap(err, "error reading JSON response")
+	}
+	return count, nil
+}
+
 // GetBlock returns the compact block at the requested height, first by querying
 // the cache, then, if not found, will request the block from zcashd. It returns
 // nil if no block exists at this height.
@@ -371,6 +820,9 @@ func GetBlock(cache *BlockCache, height int) (*walletrpc.CompactBlock, error) {
 	}
 
 	// Not in the cache, ask zcashd
+	if err := NodeUnavailable(); err != nil {
+		return nil, err
+	}
 	block, err := getBlockFromRPC(height)
 	if err != nil {
 		return nil, err
@@ -382,6 +834,171 @@ func GetBlock(cache *BlockCache, height int) (*walletrpc.CompactBlock, error) {
 	return block, nil
 }
 
+// GetBlockByHash returns the compact block with the given hash (little-endian
+// wire order), first by querying the cache, then, if not found, will request
+// the block from zcashd. It returns an error if no such block exists.
+func GetBlockByHash(cache *BlockCache, hash []byte) (*walletrpc.CompactBlock, error) {
+	block := cache.GetByHash(hash)
+	if block != nil {
+		return block, nil
+	}
+
+	// Not in the cache (or no longer on the best chain), ask zcashd
+	if err := NodeUnavailable(); err != nil {
+		return nil, err
+	}
+	block, err := getBlockByHashFromRPC(hash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block requested is not found")
+	}
+	return block, nil
+}
+
+// GetBlockIDHeight returns the height of the block specified by the given
+// block identifier. If it includes a hash, that takes precedence over the
+// height; the height is then looked up in the cache, then, if not found,
+// by requesting the block from zcashd.
+func GetBlockIDHeight(cache *BlockCache, id *walletrpc.BlockID) (int, error) {
+	if id.Hash == nil {
+		return int(id.Height), nil
+	}
+	if height := cache.GetHeightByHash(id.Hash); height >= 0 {
+		return height, nil
+	}
+	block, err := GetBlockByHash(cache, id.Hash)
+	if err != nil {
+		return 0, err
+	}
+	return int(block.Height), nil
+}
+
+// getFullBlockFromRPCByBlockID returns the full block with the given hash,
+// or else height, from zcashd.
+func getFullBlockFromRPCByBlockID(id *walletrpc.BlockID) (*parser.Block, error) {
+	if err := NodeUnavailable(); err != nil {
+		return nil, err
+	}
+	var blockID string
+	if id.Hash != nil {
+		blockID = hex.EncodeToString(parser.Reverse(id.Hash))
+	} else {
+		blockID = strconv.Itoa(int(id.Height))
+	}
+	block, err := getFullBlockFromRPCByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block requested is not found")
+	}
+	if id.Hash != nil && !bytes.Equal(block.GetEncodableHash(), id.Hash) {
+		return nil, errors.New("received unexpected hash block")
+	}
+	if id.Hash == nil && block.GetHeight() != int(id.Height) {
+		return nil, errors.New("received unexpected height block")
+	}
+	return block, nil
+}
+
+// GetBlockTransaction returns the transaction at the given index within the
+// block specified by the given block identifier (a hash takes precedence over
+// the height). The full block is requested from zcashd, since the cache holds
+// only compact blocks, so this works even if zcashd isn't run with -txindex.
+func GetBlockTransaction(id *walletrpc.BlockID, index int) (*walletrpc.RawTransaction, error) {
+	block, err := getFullBlockFromRPCByBlockID(id)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= block.GetTxCount() {
+		return nil, errors.New("transaction index is out of range")
+	}
+	return &walletrpc.RawTransaction{
+		Data:   block.Transactions()[index].Bytes(),
+		Height: uint64(block.GetHeight()),
+	}, nil
+}
+
+// GetCachedTransaction returns the mined transaction with the given txid
+// (little-endian wire order) if the cache knows which block it's in, by
+// requesting that block from zcashd; otherwise it returns nil (and no error).
+func GetCachedTransaction(cache *BlockCache, txid []byte) (*walletrpc.RawTransaction, error) {
+	height, index := cache.GetTxLocation(txid)
+	if height < 0 {
+		return nil, nil
+	}
+	block, err := getFullBlockFromRPC(height)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || index >= block.GetTxCount() {
+		return nil, errors.New("block requested is not found")
+	}
+	tx := block.Transactions()[index]
+	if !bytes.Equal(tx.GetEncodableHash(), txid) {
+		// zcashd has reorged past the cache
+		return nil, errors.New("transaction is no longer at its cached location")
+	}
+	return &walletrpc.RawTransaction{
+		Data:   tx.Bytes(),
+		Height: uint64(height),
+	}, nil
+}
+
+// GetTransactionProof returns a merkle inclusion proof for the mined
+// transaction with the given txid (little-endian wire order), from the block
+// the cache has it in or else the one zcashd reports it in.
+func GetTransactionProof(cache *BlockCache, txid []byte) (*walletrpc.TransactionProof, error) {
+	if err := NodeUnavailable(); err != nil {
+		return nil, err
+	}
+	height, _ := cache.GetTxLocation(txid)
+	if height < 0 {
+		txidJSON, err := json.Marshal(hex.EncodeToString(parser.Reverse(txid)))
+		if err != nil {
+			return nil, err
+		}
+		result, rpcErr := RawRequest("getrawtransaction", []json.RawMessage{txidJSON, json.RawMessage("1")})
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		var txinfo ZcashdRpcReplyGetrawtransaction
+		if err := json.Unmarshal(result, &txinfo); err != nil {
+			return nil, err
+		}
+		if txinfo.Height <= 0 {
+			return nil, errors.New("transaction is not in a block")
+		}
+		height = txinfo.Height
+	}
+	block, err := getFullBlockFromRPC(height)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block requested is not found")
+	}
+	for index, tx := range block.Transactions() {
+		if bytes.Equal(tx.GetEncodableHash(), txid) {
+			return block.TransactionProof(index)
+		}
+	}
+	// zcashd has reorged past the cache
+	return nil, errors.New("transaction is no longer in its block")
+}
+
+// GetBlockTransactionProof is like GetTransactionProof, for the transaction at
+// the given index within the block specified by the given block identifier.
+func GetBlockTransactionProof(id *walletrpc.BlockID, index int) (*walletrpc.TransactionProof, error) {
+	block, err := getFullBlockFromRPCByBlockID(id)
+	if err != nil {
+		return nil, err
+	}
+	return block.TransactionProof(index)
+}
+
 // GetBlockRange returns a sequence of consecutive blocks in the given range.
 func GetBlockRange(cache *BlockCache, blockOut chan<- *walletrpc.CompactBlock, errOut chan<- error, start, end int) {
 	// Go over [start, end] inclusive
@@ -407,6 +1024,26 @@ func GetBlockRange(cache *BlockCache, blockOut chan<- *walletrpc.CompactBlock, e
 	errOut <- nil
 }
 
+// blockTxids returns the txids (little-endian wire order) of all of the
+// block's transactions, in order.
+// addBlock adds the block, in compact form, to the cache, along with its
+// txids and header.
+func addBlock(c *BlockCache, height int, fullBlock *parser.Block, block *walletrpc.CompactBlock) error {
+	header, err := fullBlock.MarshalHeader()
+	if err != nil {
+		return errors.Wrap(err, "serializing block header")
+	}
+	return c.AddWithTxids(height, block, blockTxids(fullBlock), header)
+}
+
+func blockTxids(block *parser.Block) [][]byte {
+	txids := make([][]byte, block.GetTxCount())
+	for i, tx := range block.Transactions() {
+		txids[i] = tx.GetEncodableHash()
+	}
+	return txids
+}
+
 func displayHash(hash []byte) string {
 	return hex.EncodeToString(parser.Reverse(hash))
 }
@@ -6,17 +6,23 @@ package common
 import (
 	"bufio"
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/asherda/lightwalletd/parser"
 	"github.com/asherda/lightwalletd/walletrpc"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ------------------------------------------ Setup
@@ -62,7 +68,8 @@ func TestMain(m *testing.M) {
 		blockJSON, _ := json.Marshal(scan.Text())
 		blocks = append(blocks, blockJSON)
 	}
-	testcache = NewBlockCache(unitTestPath, unitTestChain, 380640, true)
+	testcache = openTestCache(380640, true)
+	Time.After = time.After
 
 	// Setup is done; run all tests.
 	exitcode := m.Run()
@@ -81,6 +88,12 @@ func sleepStub(d time.Duration) {
 	sleepCount++
 	sleepDuration += d
 }
+func afterStub(d time.Duration) <-chan time.Time {
+	sleepStub(d)
+	c := make(chan time.Time, 1)
+	c <- nowStub()
+	return c
+}
 func nowStub() time.Time {
 	start := time.Time{}
 	return start.Add(sleepDuration)
@@ -353,17 +366,417 @@ func TestBlockIngestor(t *testing.T) {
 	testT = t
 	RawRequest = blockIngestorStub
 	Time.Sleep = sleepStub
+	Time.After = afterStub
 	Time.Now = nowStub
 	os.RemoveAll(unitTestPath)
-	testcache = NewBlockCache(unitTestPath, unitTestChain, 380640, false)
+	testcache = openTestCache(380640, false)
 	BlockIngestor(testcache, 11)
 	if step != 19 {
 		t.Error("unexpected final step", step)
 	}
+	Time.After = time.After
+	step = 0
+	sleepCount = 0
+	sleepDuration = 0
+	os.RemoveAll(unitTestPath)
+}
+
+// Serve the four test blocks by height, concurrently, more slowly for lower
+// heights so the requests complete out of order.
+var (
+	pipelineMutex    sync.Mutex
+	pipelineHeights  []int // heights requested
+	pipelineActive   int   // requests in progress
+	pipelineMaxCount int   // most requests in progress at once
+)
+
+func pipelineStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	switch method {
+	case "getbestblockhash":
+		// This hash doesn't matter, won't match anything
+		r, _ := json.Marshal("010101")
+		return r, nil
+	case "getblockcount":
+		return json.RawMessage("380660"), nil
+	case "getblock":
+	default:
+		testT.Error("unexpected method", method)
+		return nil, errors.New("unexpected method")
+	}
+	var heightStr string
+	if err := json.Unmarshal(params[0], &heightStr); err != nil {
+		testT.Error("could not unmarshal height")
+	}
+	height, _ := strconv.Atoi(heightStr)
+
+	pipelineMutex.Lock()
+	pipelineHeights = append(pipelineHeights, height)
+	pipelineActive++
+	if pipelineActive > pipelineMaxCount {
+		pipelineMaxCount = pipelineActive
+	}
+	pipelineMutex.Unlock()
+	defer func() {
+		pipelineMutex.Lock()
+		pipelineActive--
+		pipelineMutex.Unlock()
+	}()
+
+	i := height - 380640
+	if i < 0 || i >= len(blocks) {
+		return nil, errors.New("-8: Block height out of range")
+	}
+	time.Sleep(time.Duration(len(blocks)-i) * 5 * time.Millisecond)
+	return blocks[i], nil
+}
+
+func resetPipelineStub() {
+	pipelineHeights = nil
+	pipelineActive = 0
+	pipelineMaxCount = 0
+}
+
+func TestFetchBlocks(t *testing.T) {
+	testT = t
+	RawRequest = pipelineStub
+	resetPipelineStub()
+
+	done := make(chan struct{})
+	defer close(done)
+	next := 380640
+	for result := range fetchBlocks(380640, 380643, 2, done) {
+		r := <-result
+		if r.err != nil {
+			t.Fatal("fetchBlocks failed:", r.err)
+		}
+		if r.block.GetHeight() != next {
+			t.Fatal("fetchBlocks unexpected height ", r.block.GetHeight(), " expecting: ", next)
+		}
+		next++
+	}
+	if next != 380644 {
+		t.Fatal("fetchBlocks returned too few blocks")
+	}
+	if pipelineMaxCount < 1 || pipelineMaxCount > 2 {
+		t.Fatal("unexpected number of concurrent requests: ", pipelineMaxCount)
+	}
+
+	// Stopping early shouldn't leave anything blocked.
+	stop := make(chan struct{})
+	results := fetchBlocks(380640, 380643, 2, stop)
+	<-<-results
+	close(stop)
+	for range results {
+	}
+}
+
+func TestBlockIngestorPipelined(t *testing.T) {
+	testT = t
+	RawRequest = pipelineStub
+	resetPipelineStub()
+	Time.Sleep = sleepStub
+	Time.Now = nowStub
+	SyncWorkers = 2
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+
+	// How many of the test blocks form a chain?
+	expected := 1
+	prev := parseTestBlock(t, 0)
+	for i := 1; i < len(blocks); i++ {
+		block := parseTestBlock(t, i)
+		if !bytes.Equal(block.GetPrevHash(), prev.GetEncodableHash()) {
+			break
+		}
+		prev = block
+		expected++
+	}
+
+	// One pass fetches ahead (up to pipelineTipDistance below the tip)
+	// and adds blocks until one doesn't extend the chain.
+	BlockIngestor(testcache, 1)
+	if testcache.GetNextHeight() != 380640+expected {
+		t.Fatal("unexpected next height ", testcache.GetNextHeight())
+	}
+	for _, height := range pipelineHeights {
+		if height < 380640 || height > 380660-pipelineTipDistance {
+			t.Fatal("unexpected getblock height ", height)
+		}
+	}
+	if pipelineMaxCount > 2 {
+		t.Fatal("unexpected number of concurrent requests: ", pipelineMaxCount)
+	}
+	SyncWorkers = 0
+	os.RemoveAll(unitTestPath)
+}
+
+func parseTestBlock(t *testing.T, i int) *parser.Block {
+	var blockHex string
+	json.Unmarshal(blocks[i], &blockHex)
+	blockData, _ := hex.DecodeString(blockHex)
+	block := parser.NewBlock()
+	if _, err := block.ParseFromSlice(blockData); err != nil {
+		t.Fatal("could not parse test block", err)
+	}
+	return block
+}
+
+// zcashd fails the first few requests, then reports the cache is synced.
+var nodeDownRequests int
+
+func nodeDownStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	if method != "getbestblockhash" {
+		testT.Fatal("unexpected method ", method)
+	}
+	if step <= nodeDownRequests {
+		return nil, errors.New("connection refused")
+	}
+	r, _ := json.Marshal(displayHash(testcache.GetLatestHash()))
+	return r, nil
+}
+
+func TestBlockIngestorRetry(t *testing.T) {
+	testT = t
+	RawRequest = nodeDownStub
+	Time.Sleep = sleepStub
+	Time.After = afterStub
+	Time.Now = nowStub
 	step = 0
 	sleepCount = 0
 	sleepDuration = 0
 	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+	defer func() {
+		Time.After = time.After
+		setNodeHealthy()
+		MaxNodeOutage = 0
+		logger.ExitFunc = nil
+		step = 0
+		sleepCount = 0
+		sleepDuration = 0
+		os.RemoveAll(unitTestPath)
+	}()
+
+	// Each failure is retried after a longer (jittered) delay.
+	nodeDownRequests = 3
+	BlockIngestor(testcache, 3)
+	if step != 3 || sleepCount != 3 {
+		t.Fatal("unexpected step ", step, " sleepCount ", sleepCount)
+	}
+	if sleepDuration < 3500*time.Millisecond || sleepDuration >= 7*time.Second {
+		t.Fatal("unexpected backoff ", sleepDuration)
+	}
+	if NodeError() == nil {
+		t.Fatal("zcashd should be unhealthy")
+	}
+	if status.Code(NodeUnavailable()) != codes.Unavailable {
+		t.Fatal("unexpected NodeUnavailable ", NodeUnavailable())
+	}
+	// Cached blocks are still available, but not others.
+	if _, err := GetBlock(testcache, 380640); status.Code(err) != codes.Unavailable {
+		t.Fatal("unexpected GetBlock error ", err)
+	}
+	if _, err := GetLightdInfo(); status.Code(err) != codes.Unavailable {
+		t.Fatal("unexpected GetLightdInfo error ", err)
+	}
+
+	// zcashd is back.
+	BlockIngestor(testcache, 1)
+	if NodeError() != nil || NodeUnavailable() != nil {
+		t.Fatal("zcashd should be healthy")
+	}
+
+	// Give up after the configured outage (which, with the shortest jittered
+	// delays, takes 26 failures).
+	exited := 0
+	logger.ExitFunc = func(int) { exited++ }
+	MaxNodeOutage = 10 * time.Minute
+	step = 0
+	nodeDownRequests = 40
+	BlockIngestor(testcache, 40)
+	if exited == 0 {
+		t.Fatal("BlockIngestor should have exited")
+	}
+	if sleepDuration < MaxNodeOutage {
+		t.Fatal("BlockIngestor exited too soon ", sleepDuration)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	for failures, max := range []time.Duration{
+		0, 1, 2, 4, 8, 16, 32, 60, 60, 60,
+	} {
+		if failures == 0 {
+			continue
+		}
+		max *= time.Second
+		for i := 0; i < 20; i++ {
+			if d := retryDelay(failures); d < max/2 || d >= max {
+				t.Fatal("unexpected delay ", d, " after ", failures, " failures")
+			}
+		}
+	}
+}
+
+// zcashd's best block height, for Readiness (or -1 if it can't be reached)
+var readinessTip int
+
+func readinessStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	if method != "getblockcount" {
+		testT.Fatal("unexpected method ", method)
+	}
+	if readinessTip < 0 {
+		return nil, errors.New("connection refused")
+	}
+	return json.Marshal(readinessTip)
+}
+
+func TestReadiness(t *testing.T) {
+	testT = t
+	RawRequest = readinessStub
+	Time.Sleep = sleepStub
+	Time.Now = nowStub
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+	defer func() {
+		sleepCount = 0
+		sleepDuration = 0
+		os.RemoveAll(unitTestPath)
+	}()
+
+	readinessTip = 380640
+	if Readiness(testcache) == nil {
+		t.Fatal("should not be ready with an empty cache")
+	}
+	block := parseTestBlock(t, 0).ToCompact()
+	if err := testcache.Add(380640, block); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+	if err := Readiness(testcache); err != nil {
+		t.Fatal("should be ready, but ", err)
+	}
+	readinessTip = 380640 + MaxSyncLag
+	if err := Readiness(testcache); err != nil {
+		t.Fatal("should be ready, but ", err)
+	}
+	readinessTip++
+	if Readiness(testcache) == nil {
+		t.Fatal("should not be ready, too far behind")
+	}
+	readinessTip = -1
+	if Readiness(testcache) == nil {
+		t.Fatal("should not be ready, zcashd is unreachable")
+	}
+	readinessTip = 380640
+	setNodeError(errors.New("connection refused"))
+	if Readiness(testcache) == nil {
+		t.Fatal("should not be ready, zcashd is unhealthy")
+	}
+	setNodeHealthy()
+
+	// HealthWatcher reports each check.
+	var reports []error
+	readinessTip = 380640 + MaxSyncLag + 1
+	HealthWatcher(testcache, func(notReady error) {
+		reports = append(reports, notReady)
+		readinessTip = 380640
+	}, 2)
+	if len(reports) != 2 || reports[0] == nil || reports[1] != nil {
+		t.Fatal("unexpected HealthWatcher reports ", reports)
+	}
+	if sleepCount != 2 || sleepDuration != 2*healthCheckInterval {
+		t.Fatal("unexpected HealthWatcher sleeps ", sleepCount, sleepDuration)
+	}
+}
+
+func TestStopBlockIngestor(t *testing.T) {
+	if StopBlockIngestor(10 * time.Millisecond) {
+		t.Fatal("StopBlockIngestor succeeded with no ingestor running")
+	}
+
+	// The ingestor polls zcashd, which reports the cache is synced.
+	testT = t
+	step = 0
+	nodeDownRequests = 0
+	RawRequest = nodeDownStub
+	Time.Sleep = func(d time.Duration) {}
+	Time.Now = time.Now
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+	defer os.RemoveAll(unitTestPath)
+	done := make(chan struct{})
+	go func() {
+		BlockIngestor(testcache, 0)
+		close(done)
+	}()
+	if !StopBlockIngestor(10 * time.Second) {
+		t.Fatal("StopBlockIngestor failed")
+	}
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("BlockIngestor didn't stop")
+	}
+
+	// It stops during a retry backoff (that would otherwise never end).
+	step = 0
+	nodeDownRequests = 1000
+	Time.After = func(time.Duration) <-chan time.Time { return nil }
+	defer func() {
+		Time.After = time.After
+		setNodeHealthy()
+	}()
+	go BlockIngestor(testcache, 0)
+	for NodeError() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	if !StopBlockIngestor(10 * time.Second) {
+		t.Fatal("StopBlockIngestor failed during a backoff")
+	}
+	if step != 1 {
+		t.Fatal("unexpected step ", step)
+	}
+
+	// It stops during a pipelined catch-up, while waiting for a block.
+	requested := make(chan struct{})
+	release := make(chan struct{})
+	RawRequest = func(method string, params []json.RawMessage) (json.RawMessage, error) {
+		switch method {
+		case "getbestblockhash":
+			r, _ := json.Marshal("010101")
+			return r, nil
+		case "getblockcount":
+			return json.RawMessage("380660"), nil
+		}
+		var height string
+		json.Unmarshal(params[0], &height)
+		if height == "380640" {
+			return blocks[0], nil
+		}
+		if height == "380641" {
+			close(requested)
+		}
+		<-release
+		return nil, errors.New("-8: Block height out of range")
+	}
+	SyncWorkers = 2
+	defer func() { SyncWorkers = 0 }()
+	go BlockIngestor(testcache, 0)
+	<-requested
+	stopped := make(chan bool)
+	go func() { stopped <- StopBlockIngestor(10 * time.Second) }()
+	// The ingestor accepts the stop, but returns only once the requests in
+	// progress are done.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	if !<-stopped {
+		t.Fatal("StopBlockIngestor failed during a pipelined sync")
+	}
+	if testcache.GetNextHeight() != 380641 {
+		t.Fatal("unexpected next height ", testcache.GetNextHeight())
+	}
+	step = 0
 }
 
 // ------------------------------------------ GetBlockRange()
@@ -488,7 +901,7 @@ func TestGetBlockRange(t *testing.T) {
 	testT = t
 	RawRequest = getblockStub
 	os.RemoveAll(unitTestPath)
-	testcache = NewBlockCache(unitTestPath, unitTestChain, 380640, true)
+	testcache = openTestCache(380640, true)
 	blockChan := make(chan *walletrpc.CompactBlock)
 	errChan := make(chan error)
 	go GetBlockRange(testcache, blockChan, errChan, 380640, 380642)
@@ -567,7 +980,7 @@ func TestGetBlockRangeReverse(t *testing.T) {
 	testT = t
 	RawRequest = getblockStubReverse
 	os.RemoveAll(unitTestPath)
-	testcache = NewBlockCache(unitTestPath, unitTestChain, 380640, true)
+	testcache = openTestCache(380640, true)
 	blockChan := make(chan *walletrpc.CompactBlock)
 	errChan := make(chan error)
 
@@ -618,6 +1031,27 @@ func TestGenerateCerts(t *testing.T) {
 
 // ------------------------------------------ GetMempoolStream
 
+// waitMempoolIdle waits for an earlier test's mempoolTracker to exit, and
+// discards any pending request for it to poll.
+func waitMempoolIdle(t *testing.T) {
+	for i := 0; ; i++ {
+		g_lock.Lock()
+		running := g_trackerRunning
+		g_lock.Unlock()
+		if !running {
+			break
+		}
+		if i == 500 {
+			t.Fatal("mempoolTracker is still running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case <-mempoolPollChan:
+	default:
+	}
+}
+
 // Note that in mocking zcashd's RPC replies here, we don't really need
 // actual txids or transactions, or even strings with the correct format
 // for those, except that a transaction must be a hex string.
@@ -635,16 +1069,6 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 		})
 		return r, nil
 	case 2:
-		// No new block has arrived.
-		if method != "getblockchaininfo" {
-			testT.Fatal("expecting blockchaininfo")
-		}
-		r, _ := json.Marshal(&ZcashdRpcReplyGetblockchaininfo{
-			BestBlockHash: "010203",
-			Blocks:        200,
-		})
-		return r, nil
-	case 3:
 		// Expect a getrawmempool next.
 		if method != "getrawmempool" {
 			testT.Fatal("expecting getrawmempool")
@@ -654,7 +1078,7 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 			"mempooltxid-1",
 		})
 		return r, nil
-	case 4:
+	case 3:
 		// Next, it should ask for this transaction (non-verbose).
 		if method != "getrawtransaction" {
 			testT.Fatal("expecting getrawtransaction")
@@ -666,7 +1090,7 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 		}
 		r, _ := json.Marshal("aabb")
 		return r, nil
-	case 5:
+	case 4:
 		// Simulate that still no new block has arrived ...
 		if method != "getblockchaininfo" {
 			testT.Fatal("expecting blockchaininfo")
@@ -676,7 +1100,7 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 			Blocks:        200,
 		})
 		return r, nil
-	case 6:
+	case 5:
 		// ... but there a second tx has arrived in the mempool
 		if method != "getrawmempool" {
 			testT.Fatal("expecting getrawmempool")
@@ -686,7 +1110,7 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 			"mempooltxid-2",
 			"mempooltxid-1"})
 		return r, nil
-	case 7:
+	case 6:
 		// The new mempool tx (and only that one) gets fetched
 		if method != "getrawtransaction" {
 			testT.Fatal("expecting getrawtransaction")
@@ -698,8 +1122,8 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 		}
 		r, _ := json.Marshal("ccdd")
 		return r, nil
-	case 8:
-		// A new block arrives, this will cause these two tx to be returned
+	case 7:
+		// A new block arrives, this will end the streams
 		if method != "getblockchaininfo" {
 			testT.Fatal("expecting blockchaininfo")
 		}
@@ -708,6 +1132,13 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 			Blocks:        201,
 		})
 		return r, nil
+	case 8:
+		// The new epoch's mempool is empty
+		if method != "getrawmempool" {
+			testT.Fatal("expecting getrawmempool")
+		}
+		r, _ := json.Marshal([]string{})
+		return r, nil
 	}
 	testT.Fatal("ran out of cases")
 	return nil, nil
@@ -715,58 +1146,80 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 
 func TestMempoolStream(t *testing.T) {
 	testT = t
+	waitMempoolIdle(t)
 	RawRequest = mempoolStub
-	Time.Sleep = sleepStub
-	Time.Now = nowStub
-	// In real life, wall time is not close to zero, simulate that.
-	sleepDuration = 1000 * time.Second
-
-	var replies []*walletrpc.RawTransaction
-	// The first request after startup immediately returns an empty list.
-	err := GetMempool(func(tx *walletrpc.RawTransaction) error {
-		t.Fatal("send to client function called on initial GetMempool call")
-		return nil
-	})
-	if err != nil {
-		t.Fatal("GetMempool failed")
-	}
+	step = 0
+	Time.Now = time.Now
+	g_lastTime = time.Time{}
+	g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+	// Polls happen only when the test asks.
+	mempoolPollInterval = time.Hour
+	defer func() {
+		mempoolPollInterval = 2 * time.Second
+		g_txidSeen = map[txid]struct{}{}
+		g_epoch = newMempoolEpoch()
+		g_lastTime = time.Time{}
+		g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+		step = 0
+	}()
 
-	// This should return two transactions.
-	err = GetMempool(func(tx *walletrpc.RawTransaction) error {
-		replies = append(replies, tx)
-		return nil
-	})
-	if err != nil {
-		t.Fatal("GetMempool failed")
+	type client struct {
+		replies chan *walletrpc.RawTransaction
+		done    chan error
+	}
+	startClient := func(release chan struct{}) *client {
+		c := &client{
+			replies: make(chan *walletrpc.RawTransaction, 10),
+			done:    make(chan error, 1),
+		}
+		go func() {
+			c.done <- GetMempool(func(tx *walletrpc.RawTransaction) error {
+				if release != nil {
+					<-release
+				}
+				c.replies <- tx
+				return nil
+			})
+		}()
+		return c
 	}
-	if len(replies) != 2 {
-		t.Fatal("unexpected number of tx")
+	expect := func(c *client, data []byte) {
+		select {
+		case tx := <-c.replies:
+			if !bytes.Equal(tx.GetData(), data) || tx.GetHeight() != 200 {
+				t.Fatal("unexpected tx ", tx)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("transaction not sent")
+		}
 	}
+
+	// The first subscriber starts the tracker, which polls right away.
+	fast := startClient(nil)
+	expect(fast, []byte{0xaa, 0xbb})
+
+	// A later subscriber gets the transactions since the latest block (but
+	// a slow one doesn't hold up the others).
+	release := make(chan struct{})
+	slow := startClient(release)
+	mempoolPollChan <- struct{}{}
+	expect(fast, []byte{0xcc, 0xdd})
+	close(release)
 	// The interface guarantees that the transactions will be returned
 	// in the order they entered the mempool.
-	if !bytes.Equal([]byte(replies[0].GetData()), []byte{0xaa, 0xbb}) {
-		t.Fatal("unexpected tx contents")
-	}
-	if replies[0].GetHeight() != 200 {
-		t.Fatal("unexpected tx height")
-	}
-	if !bytes.Equal([]byte(replies[1].GetData()), []byte{0xcc, 0xdd}) {
-		t.Fatal("unexpected tx contents")
-	}
-	if replies[1].GetHeight() != 200 {
-		t.Fatal("unexpected tx height")
-	}
+	expect(slow, []byte{0xaa, 0xbb})
+	expect(slow, []byte{0xcc, 0xdd})
 
-	// Time started at 1000 seconds (since 1970), and just over 4 seconds
-	// should have elapsed. The units here are nanoseconds.
-	if sleepDuration != 1004400000000 {
-		t.Fatal("unexpected end time")
+	// A new block ends the streams.
+	mempoolPollChan <- struct{}{}
+	for _, c := range []*client{fast, slow} {
+		if err := <-c.done; err != nil {
+			t.Fatal("GetMempool failed ", err)
+		}
 	}
+	waitMempoolIdle(t)
+	// However many clients, zcashd is polled only as for one.
 	if step != 8 {
-		t.Fatal("unexpected number of zcashd RPCs")
+		t.Fatal("unexpected number of zcashd RPCs ", step)
 	}
-
-	step = 0
-	sleepCount = 0
-	sleepDuration = 0
 }
@@ -0,0 +1,181 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// satoshisPerCoin is the number of satoshis in one coin of any currency.
+const satoshisPerCoin = 100000000
+
+// CoinsToSatoshis converts an amount as reported by the node's rpcs (in
+// coins, such as 1.23456789) to satoshis, exactly.
+func CoinsToSatoshis(amount json.Number) (int64, error) {
+	r, ok := new(big.Rat).SetString(string(amount))
+	if !ok {
+		return 0, errors.Errorf("invalid amount %q", amount)
+	}
+	r.Mul(r, big.NewRat(satoshisPerCoin, 1))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, errors.Errorf("invalid amount %q", amount)
+	}
+	return r.Num().Int64(), nil
+}
+
+// SatoshisToCoins is the inverse of CoinsToSatoshis.
+func SatoshisToCoins(satoshis int64) json.Number {
+	sign := ""
+	if satoshis < 0 {
+		sign = "-"
+		satoshis = -satoshis
+	}
+	return json.Number(fmt.Sprintf("%s%d.%08d", sign, satoshis/satoshisPerCoin, satoshis%satoshisPerCoin))
+}
+
+// CurrencyValues converts a map of currency (i-address) to amount in coins,
+// as in the node's getaddressbalance and getaddressutxos replies, to one of
+// amounts in satoshis. It returns nil if there are none.
+func CurrencyValues(values map[string]json.Number) (map[string]int64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	satoshis := make(map[string]int64, len(values))
+	for currency, amount := range values {
+		var err error
+		if satoshis[currency], err = CoinsToSatoshis(amount); err != nil {
+			return nil, errors.Wrap(err, currency)
+		}
+	}
+	return satoshis, nil
+}
+
+// cachedBlockRequest is RawRequest for rpcs whose results depend only on the
+// block at the given height (or the latest block, if height is negative).
+// The results are stored in the cache as of that block (by its hash), so
+// they're dropped if it's reorged away.
+func cachedBlockRequest(cache *BlockCache, height int, method string, params []json.RawMessage) (json.RawMessage, error) {
+	latest := height < 0
+	if latest {
+		height = cache.GetLatestHeight()
+	}
+	request := requestKey(method, params)
+	result, hash := cache.GetBlockResult(height, request)
+	if result != nil {
+		return result, nil
+	}
+	if err := NodeUnavailable(); err != nil {
+		return nil, err
+	}
+	result, err := RawRequest(method, params)
+	if err != nil {
+		return nil, err
+	}
+	// A result as of the latest block is only known to be as of this block
+	// if no other block arrived during the request.
+	if hash != nil && (!latest || bytes.Equal(cache.GetLatestHash(), hash)) {
+		cache.PutBlockResult(height, hash, request, result)
+	}
+	return result, nil
+}
+
+// GetCurrency returns the definition of the currency with the given name
+// (such as "VRSC") or i-address, as of the latest block.
+func GetCurrency(cache *BlockCache, currency string) (*ZcashdRpcReplyGetcurrency, error) {
+	if currency == "" {
+		return nil, errors.New("currency must be specified")
+	}
+	currencyJSON, _ := json.Marshal(currency)
+	result, err := cachedBlockRequest(cache, -1, "getcurrency", []json.RawMessage{currencyJSON})
+	if err != nil {
+		return nil, err
+	}
+	var reply ZcashdRpcReplyGetcurrency
+	if err := json.Unmarshal(result, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// ListCurrencies returns the definitions of the currencies selected by the
+// given query (all of them, if it's empty), as of the latest block.
+func ListCurrencies(cache *BlockCache, query *ZcashdRpcRequestListcurrencies) (ZcashdRpcReplyListcurrencies, error) {
+	params := make([]json.RawMessage, 0, 1)
+	if *query != (ZcashdRpcRequestListcurrencies{}) {
+		queryJSON, err := json.Marshal(query)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, queryJSON)
+	}
+	result, err := cachedBlockRequest(cache, -1, "listcurrencies", params)
+	if err != nil {
+		return nil, err
+	}
+	var reply ZcashdRpcReplyListcurrencies
+	if err := json.Unmarshal(result, &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// GetCurrencyState returns the state of the given currency at the given block
+// height, or at the latest block if height is zero.
+func GetCurrencyState(cache *BlockCache, currency string, height uint64) (*ZcashdRpcCurrencyState, uint64, error) {
+	if currency == "" {
+		return nil, 0, errors.New("currency must be specified")
+	}
+	if height == 0 {
+		latest := cache.GetLatestHeight()
+		if latest < 0 {
+			return nil, 0, errors.New("no blocks are available yet")
+		}
+		height = uint64(latest)
+	}
+	currencyJSON, _ := json.Marshal(currency)
+	// The height is a string, since the rpc also accepts ranges ("m,n").
+	heightJSON, _ := json.Marshal(strconv.FormatUint(height, 10))
+	result, err := cachedBlockRequest(cache, int(height), "getcurrencystate",
+		[]json.RawMessage{currencyJSON, heightJSON})
+	if err != nil {
+		return nil, 0, err
+	}
+	var reply ZcashdRpcReplyGetcurrencystate
+	if err := json.Unmarshal(result, &reply); err != nil {
+		return nil, 0, err
+	}
+	if len(reply) == 0 {
+		return nil, 0, errors.New("currency state is not available at this height")
+	}
+	entry := reply[len(reply)-1]
+	return &entry.CurrencyState, entry.Height, nil
+}
+
+// EstimateConversion returns the node's estimate of the result of the given
+// currency conversion, as of the latest block.
+func EstimateConversion(cache *BlockCache, conversion *ZcashdRpcRequestEstimateconversion) (*ZcashdRpcReplyEstimateconversion, error) {
+	if conversion.Currency == "" || conversion.ConvertTo == "" {
+		return nil, errors.New("currency and convertto must be specified")
+	}
+	conversionJSON, err := json.Marshal(conversion)
+	if err != nil {
+		return nil, err
+	}
+	result, err := cachedRequest(cache, "estimateconversion", []json.RawMessage{conversionJSON})
+	if err != nil {
+		return nil, err
+	}
+	var reply ZcashdRpcReplyEstimateconversion
+	if err := json.Unmarshal(result, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
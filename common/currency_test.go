@@ -0,0 +1,232 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestCoinsToSatoshis(t *testing.T) {
+	for _, tc := range []struct {
+		coins    json.Number
+		satoshis int64
+	}{
+		{"0", 0},
+		{"0.00000000", 0},
+		{"1", 100000000},
+		{"1.23456789", 123456789},
+		{"0.1", 10000000},
+		{"21000000000.00000001", 2100000000000000001},
+		{"-0.5", -50000000},
+		{"1e-8", 1},
+	} {
+		satoshis, err := CoinsToSatoshis(tc.coins)
+		if err != nil || satoshis != tc.satoshis {
+			t.Fatal("CoinsToSatoshis unexpected result ", tc.coins, satoshis, err)
+		}
+		if tc.coins != "1e-8" && tc.coins != "0" {
+			if back, _ := CoinsToSatoshis(SatoshisToCoins(satoshis)); back != satoshis {
+				t.Fatal("SatoshisToCoins didn't round-trip ", tc.coins, SatoshisToCoins(satoshis))
+			}
+		}
+	}
+	if SatoshisToCoins(-123456789) != "-1.23456789" {
+		t.Fatal("SatoshisToCoins unexpected result ", SatoshisToCoins(-123456789))
+	}
+	for _, coins := range []json.Number{"", "abc", "0.000000001", "100000000000000"} {
+		if _, err := CoinsToSatoshis(coins); err == nil {
+			t.Fatal("CoinsToSatoshis should have failed ", coins)
+		}
+	}
+
+	values, err := CurrencyValues(map[string]json.Number{
+		"iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq": "2.5",
+		"i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV": "0.00000001",
+	})
+	if err != nil || len(values) != 2 ||
+		values["iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq"] != 250000000 ||
+		values["i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV"] != 1 {
+		t.Fatal("CurrencyValues unexpected result ", values, err)
+	}
+	if values, err := CurrencyValues(nil); values != nil || err != nil {
+		t.Fatal("CurrencyValues unexpected result for no currencies ", values, err)
+	}
+	if _, err := CurrencyValues(map[string]json.Number{"i": "x"}); err == nil {
+		t.Fatal("CurrencyValues should have failed")
+	}
+}
+
+// Number of requests that reached zcashd
+var currencyRequests int
+
+func currencyStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	currencyRequests++
+	var name string
+	if len(params) > 0 {
+		json.Unmarshal(params[0], &name)
+	}
+	switch method {
+	case "getcurrency":
+		if name != "VRSC" {
+			return nil, errors.New("-5: Currency not found")
+		}
+		return []byte(`{"name": "VRSC", "currencyid": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+			"notaries": ["iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq"], "minnotariesconfirm": 1,
+			"idregistrationfees": 100.00000000}`), nil
+	case "listcurrencies":
+		if len(params) != 1 || string(params[0]) != `{"systemtype":"pbaas"}` {
+			testT.Fatal("unexpected listcurrencies params ", params)
+		}
+		return []byte(`[{"currencydefinition": {"name": "VRSC"}, "bestheight": 380641}]`), nil
+	case "getcurrencystate":
+		var height string
+		json.Unmarshal(params[1], &height)
+		return []byte(`[{"height": ` + height + `, "currencystate": {"supply": 1.5}}]`), nil
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestGetCurrency(t *testing.T) {
+	testT = t
+	RawRequest = currencyStub
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+	defer func() {
+		currencyRequests = 0
+		os.RemoveAll(unitTestPath)
+	}()
+	if _, _, err := GetCurrencyState(testcache, "VRSC", 0); err == nil {
+		t.Fatal("GetCurrencyState should have failed, no blocks")
+	}
+	for i := 0; i < 2; i++ {
+		if err := testcache.Add(380640+i, parseTestBlock(t, i).ToCompact()); err != nil {
+			t.Fatal("cache.Add failed:", err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		reply, err := GetCurrency(testcache, "VRSC")
+		if err != nil {
+			t.Fatal("GetCurrency failed:", err)
+		}
+		if reply.CurrencyID != "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV" || len(reply.Notaries) != 1 ||
+			reply.IDRegistrationFees != "100.00000000" {
+			t.Fatal("unexpected GetCurrency reply ", reply)
+		}
+	}
+	if currencyRequests != 1 {
+		t.Fatal("GetCurrency should have been cached ", currencyRequests)
+	}
+	if _, err := GetCurrency(testcache, "XYZ"); err == nil {
+		t.Fatal("GetCurrency should have failed, not found")
+	}
+	if _, err := GetCurrency(testcache, ""); err == nil {
+		t.Fatal("GetCurrency should have failed, no currency")
+	}
+
+	list, err := ListCurrencies(testcache, &ZcashdRpcRequestListcurrencies{SystemType: "pbaas"})
+	if err != nil || len(list) != 1 || list[0].CurrencyDefinition.Name != "VRSC" || list[0].BestHeight != 380641 {
+		t.Fatal("unexpected ListCurrencies reply ", list, err)
+	}
+
+	// The state at the latest height and at an earlier one.
+	currencyRequests = 0
+	for i := 0; i < 2; i++ {
+		cs, height, err := GetCurrencyState(testcache, "VRSC", 0)
+		if err != nil || height != 380641 || cs.Supply != "1.5" {
+			t.Fatal("unexpected GetCurrencyState reply ", cs, height, err)
+		}
+		if _, height, _ = GetCurrencyState(testcache, "VRSC", 380640); height != 380640 {
+			t.Fatal("unexpected GetCurrencyState height ", height)
+		}
+	}
+	if currencyRequests != 2 {
+		t.Fatal("GetCurrencyState should have been cached ", currencyRequests)
+	}
+
+	// The results as of a block are kept across restarts, but not reorgs.
+	testcache.Close()
+	testcache = openTestCache(380640, false)
+	GetCurrencyState(testcache, "VRSC", 380640)
+	GetCurrencyState(testcache, "VRSC", 380641)
+	if currencyRequests != 2 {
+		t.Fatal("GetCurrencyState should have been stored ", currencyRequests)
+	}
+	testcache.Reorg(380641)
+	if err := testcache.Add(380641, parseTestBlock(t, 1).ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+	GetCurrencyState(testcache, "VRSC", 380640)
+	GetCurrencyState(testcache, "VRSC", 380641)
+	if currencyRequests != 3 {
+		t.Fatal("GetCurrencyState should have been refetched after a reorg ", currencyRequests)
+	}
+
+	// Results aren't stored for blocks not in the cache, or no longer in the best chain.
+	if _, hash := testcache.GetBlockResult(380650, "key"); hash != nil {
+		t.Fatal("GetBlockResult unexpected hash")
+	}
+	testcache.PutBlockResult(380640, make([]byte, 32), "key", json.RawMessage("1"))
+	if result, _ := testcache.GetBlockResult(380640, "key"); result != nil {
+		t.Fatal("PutBlockResult stored a result for the wrong block")
+	}
+	testcache.FlushBlockResults()
+	GetCurrencyState(testcache, "VRSC", 380641)
+	if currencyRequests != 4 {
+		t.Fatal("FlushBlockResults should have removed the results ", currencyRequests)
+	}
+}
+
+func TestDarksideCurrency(t *testing.T) {
+	defer func() {
+		state = darksideState{}
+	}()
+	state = darksideState{resetted: true, startHeight: 1000, latestHeight: 1001}
+	vrsc := ZcashdRpcReplyGetcurrency{
+		Name:              "VRSC",
+		CurrencyID:        "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+		SystemID:          "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+		BestCurrencyState: &ZcashdRpcCurrencyState{Supply: "10"},
+	}
+	if err := DarksideStageCurrency(vrsc); err != nil {
+		t.Fatal("DarksideStageCurrency failed:", err)
+	}
+	vrsc.BestCurrencyState = &ZcashdRpcCurrencyState{Supply: "20"}
+	DarksideStageCurrency(vrsc) // replaces
+	DarksideStageCurrency(ZcashdRpcReplyGetcurrency{Name: "vETH", CurrencyID: "iCtawpxUiCc2sEupt7Z4u8SDAncGZpgSKm"})
+	if DarksideStageCurrency(ZcashdRpcReplyGetcurrency{Name: "bad"}) == nil {
+		t.Fatal("DarksideStageCurrency should have failed, no currency ID")
+	}
+
+	result, err := darksideRawRequest("getcurrency", []json.RawMessage{json.RawMessage(`"vrsc"`)})
+	var c ZcashdRpcReplyGetcurrency
+	if err != nil || json.Unmarshal(result, &c) != nil || c.BestHeight != 1001 || c.BestCurrencyState.Supply != "20" {
+		t.Fatal("unexpected getcurrency reply ", string(result), err)
+	}
+	result, err = darksideRawRequest("getcurrencystate",
+		[]json.RawMessage{json.RawMessage(`"VRSC"`), json.RawMessage(`"1000"`)})
+	var cs ZcashdRpcReplyGetcurrencystate
+	if err != nil || json.Unmarshal(result, &cs) != nil || len(cs) != 1 || cs[0].Height != 1000 {
+		t.Fatal("unexpected getcurrencystate reply ", string(result), err)
+	}
+	if _, err := darksideRawRequest("getcurrencystate",
+		[]json.RawMessage{json.RawMessage(`"VRSC"`), json.RawMessage(`"1002"`)}); err == nil {
+		t.Fatal("getcurrencystate should have failed, beyond the tip")
+	}
+	result, err = darksideRawRequest("listcurrencies",
+		[]json.RawMessage{json.RawMessage(`{"fromsystem":"i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV"}`)})
+	var list ZcashdRpcReplyListcurrencies
+	if err != nil || json.Unmarshal(result, &list) != nil || len(list) != 1 {
+		t.Fatal("unexpected listcurrencies reply ", string(result), err)
+	}
+
+	DarksideClearCurrencies()
+	if _, err := darksideRawRequest("getcurrency", []json.RawMessage{json.RawMessage(`"VRSC"`)}); err == nil {
+		t.Fatal("getcurrency should have failed, cleared")
+	}
+}
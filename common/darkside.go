@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -49,6 +50,13 @@ type darksideState struct {
 
 	// Unordered list of replies
 	getAddressUtxos []ZcashdRpcReplyGetaddressutxos
+
+	// Versions of identities (unordered), each visible once latestHeight
+	// reaches its BlockHeight.
+	identities []ZcashdRpcReplyGetidentity
+
+	// Currency definitions, each with its (unchanging) best state
+	currencies []ZcashdRpcReplyGetcurrency
 }
 
 var state darksideState
@@ -175,6 +183,7 @@ func DarksideApplyStaged(height int) error {
 	// on errors.
 	stagedTransactions := state.stagedTransactions
 	state.stagedTransactions = nil
+	appended := make(map[int][]*parser.Transaction)
 	for _, tx := range stagedTransactions {
 		if tx.height < state.startHeight {
 			return errors.New("transaction height too low")
@@ -182,35 +191,25 @@ func DarksideApplyStaged(height int) error {
 		if tx.height >= state.startHeight+len(state.activeBlocks) {
 			return errors.New("transaction height too high")
 		}
-		block := state.activeBlocks[tx.height-state.startHeight]
-		// The next one or 3 bytes encode the number of transactions to follow,
-		// little endian.
-		nTxFirstByte := block[1487]
-		switch {
-		case nTxFirstByte < 252:
-			block[1487]++
-		case nTxFirstByte == 252:
-			// incrementing to 253, requires "253" followed by 2-byte length,
-			// extend the block by two bytes, shift existing transaction bytes
-			block = append(block, 0, 0)
-			copy(block[1490:], block[1488:len(block)-2])
-			block[1487] = 253
-			block[1488] = 253
-			block[1489] = 0
-		case nTxFirstByte == 253:
-			block[1488]++
-			if block[1488] == 0 {
-				// wrapped around
-				block[1489]++
-			}
-		default:
-			// no need to worry about more than 64k transactions
-			Log.Fatal("unexpected compact transaction count ", nTxFirstByte,
-				", can't support more than 64k transactions in a block")
+		transaction := parser.NewTransaction()
+		if _, err := transaction.ParseFromSlice(tx.bytes); err != nil {
+			return err
+		}
+		index := tx.height - state.startHeight
+		appended[index] = append(appended[index], transaction)
+	}
+	for index, txs := range appended {
+		block := parser.NewBlock()
+		if _, err := block.ParseFromSlice(state.activeBlocks[index]); err != nil {
+			return err
 		}
-		block[68]++ // hack HashFinalSaplingRoot to mod the block hash
-		block = append(block, tx.bytes...)
-		state.activeBlocks[tx.height-state.startHeight] = block
+		// This updates the merkle root, and so the block hash.
+		block.AppendTransactions(txs...)
+		blockBytes, err := block.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		state.activeBlocks[index] = blockBytes
 	}
 	maxHeight := state.startHeight + len(state.activeBlocks) - 1
 	if height > maxHeight {
@@ -334,17 +333,23 @@ func DarksideStageBlocksCreate(height int32, nonce int32, count int32) error {
 			Log.Fatal(err)
 		}
 
-		hashOfTxnsAndHeight := sha256.Sum256([]byte(string(nonce) + "#" + string(height)))
+		coinbase := parser.NewTransaction()
+		if _, err := coinbase.ParseFromSlice(fakeCoinbaseBytes); err != nil {
+			Log.Fatal(err)
+		}
+		// The nonce distinguishes blocks at the same height (with the
+		// same transactions), so reorgs can be simulated.
+		hashOfNonceAndHeight := sha256.Sum256([]byte(string(nonce) + "#" + string(height)))
 		blockHeader := &parser.BlockHeader{
 			RawBlockHeader: &parser.RawBlockHeader{
-				Version:              4,                      // start: 0
-				HashPrevBlock:        make([]byte, 32),       // start: 4
-				HashMerkleRoot:       hashOfTxnsAndHeight[:], // start: 36
-				HashFinalSaplingRoot: make([]byte, 32),       // start: 68
-				Time:                 1,                      // start: 100
-				NBitsBytes:           make([]byte, 4),        // start: 104
-				Nonce:                make([]byte, 32),       // start: 108
-				Solution:             make([]byte, 1344),     // starts: 140, 143
+				Version:              4,                           // start: 0
+				HashPrevBlock:        make([]byte, 32),            // start: 4
+				HashMerkleRoot:       coinbase.GetEncodableHash(), // start: 36
+				HashFinalSaplingRoot: make([]byte, 32),            // start: 68
+				Time:                 1,                           // start: 100
+				NBitsBytes:           make([]byte, 4),             // start: 104
+				Nonce:                hashOfNonceAndHeight[:],     // start: 108
+				Solution:             make([]byte, 1344),          // starts: 140, 143
 			}, // length: 1487
 		}
 
@@ -394,12 +399,16 @@ func darksideRawRequest(method string, params []json.RawMessage) (json.RawMessag
 			return nil, errors.New("failed to parse getblock request")
 		}
 
+		state.mutex.RLock()
+		defer state.mutex.RUnlock()
+		if len(heightStr) == 64 {
+			// zcashd accepts either a height or a block hash
+			return darksideGetBlockByHash(heightStr)
+		}
 		height, err := strconv.Atoi(heightStr)
 		if err != nil {
 			return nil, errors.New("error parsing height as integer")
 		}
-		state.mutex.RLock()
-		defer state.mutex.RUnlock()
 		const notFoundErr = "-8:"
 		if len(state.activeBlocks) == 0 {
 			return nil, errors.New(notFoundErr)
@@ -478,6 +487,18 @@ func darksideRawRequest(method string, params []json.RawMessage) (json.RawMessag
 		}
 		return json.Marshal(reply)
 
+	case "getidentity":
+		return darksideGetIdentity(params)
+
+	case "getidentityhistory":
+		return darksideGetIdentityHistory(params)
+
+	case "getcurrency", "getcurrencystate":
+		return darksideGetCurrency(method, params)
+
+	case "listcurrencies":
+		return darksideListCurrencies(params)
+
 	case "getaddressutxos":
 		var req ZcashdRpcRequestGetaddressutxos
 		err := json.Unmarshal(params[0], &req)
@@ -500,6 +521,28 @@ func darksideRawRequest(method string, params []json.RawMessage) (json.RawMessag
 	}
 }
 
+// Caller should hold state.mutex.RLock().
+func darksideGetBlockByHash(hashStr string) (json.RawMessage, error) {
+	hash, err := hex.DecodeString(hashStr)
+	if err != nil {
+		return nil, errors.New("-8: " + err.Error())
+	}
+	// Only blocks up to the presented tip are visible.
+	for i, blockBytes := range state.activeBlocks {
+		if state.startHeight+i > state.latestHeight {
+			break
+		}
+		block := parser.NewBlock()
+		if _, err := block.ParseFromSlice(blockBytes); err != nil {
+			continue
+		}
+		if bytes.Equal(block.GetDisplayHash(), hash) {
+			return json.Marshal(hex.EncodeToString(blockBytes))
+		}
+	}
+	return nil, errors.New("-5: Block not found")
+}
+
 func darksideGetRawTransaction(params []json.RawMessage) (json.RawMessage, error) {
 	if !state.resetted {
 		return nil, errors.New("please call Reset first")
@@ -631,3 +674,231 @@ func DarksideClearAddressUtxos() error {
 	state.getAddressUtxos = nil
 	return nil
 }
+
+// DarksideStageIdentity adds a version of an identity, to be returned by
+// getidentity and getidentityhistory once the active chain reaches its height.
+func DarksideStageIdentity(arg ZcashdRpcReplyGetidentity) error {
+	if arg.Identity.IdentityAddress == "" {
+		return errors.New("identity address must be specified")
+	}
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	state.identities = append(state.identities, arg)
+	return nil
+}
+
+func DarksideClearIdentities() error {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	state.identities = nil
+	return nil
+}
+
+// DarksideStageCurrency adds the given currency definition, or replaces the
+// one with the same currency ID.
+func DarksideStageCurrency(arg ZcashdRpcReplyGetcurrency) error {
+	if arg.CurrencyID == "" {
+		return errors.New("currency ID must be specified")
+	}
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	replaced := false
+	for i := range state.currencies {
+		if state.currencies[i].CurrencyID == arg.CurrencyID {
+			state.currencies[i] = arg
+			replaced = true
+		}
+	}
+	if !replaced {
+		state.currencies = append(state.currencies, arg)
+	}
+	// The cached replies (as of the current blocks) are now out of date.
+	if state.cache != nil {
+		state.cache.FlushBlockResults()
+	}
+	return nil
+}
+
+func DarksideClearCurrencies() error {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	state.currencies = nil
+	if state.cache != nil {
+		state.cache.FlushBlockResults()
+	}
+	return nil
+}
+
+// Caller should hold state.mutex.RLock().
+func darksideFindCurrency(name string) *ZcashdRpcReplyGetcurrency {
+	for i := range state.currencies {
+		c := &state.currencies[i]
+		if name == c.CurrencyID || strings.EqualFold(name, c.Name) ||
+			(c.FullyQualifiedName != "" && strings.EqualFold(name, c.FullyQualifiedName)) {
+			return c
+		}
+	}
+	return nil
+}
+
+func darksideGetCurrency(method string, params []json.RawMessage) (json.RawMessage, error) {
+	var name string
+	if len(params) == 0 || json.Unmarshal(params[0], &name) != nil {
+		return nil, errors.New("failed to parse " + method + " request")
+	}
+	state.mutex.RLock()
+	defer state.mutex.RUnlock()
+	c := darksideFindCurrency(name)
+	if c == nil {
+		return nil, errors.New("-5: Currency not found")
+	}
+	if method == "getcurrency" {
+		reply := *c
+		reply.BestHeight = uint64(state.latestHeight)
+		return json.Marshal(reply)
+	}
+	// getcurrencystate, at a single height
+	height := state.latestHeight
+	if len(params) > 1 {
+		var heightStr string
+		if json.Unmarshal(params[1], &heightStr) != nil {
+			return nil, errors.New("failed to parse getcurrencystate height")
+		}
+		var err error
+		if height, err = strconv.Atoi(heightStr); err != nil {
+			return nil, errors.New("-8: invalid height")
+		}
+	}
+	if height > state.latestHeight {
+		return nil, errors.New("-8: invalid height")
+	}
+	entry := ZcashdRpcCurrencyStateEntry{Height: uint64(height)}
+	if c.BestCurrencyState != nil {
+		entry.CurrencyState = *c.BestCurrencyState
+	}
+	return json.Marshal(ZcashdRpcReplyGetcurrencystate{entry})
+}
+
+// Only the fromsystem query (by system ID) is supported.
+func darksideListCurrencies(params []json.RawMessage) (json.RawMessage, error) {
+	var query ZcashdRpcRequestListcurrencies
+	if len(params) > 0 && json.Unmarshal(params[0], &query) != nil {
+		return nil, errors.New("failed to parse listcurrencies request")
+	}
+	state.mutex.RLock()
+	defer state.mutex.RUnlock()
+	reply := make(ZcashdRpcReplyListcurrencies, 0)
+	for _, c := range state.currencies {
+		if query.FromSystem != "" && query.FromSystem != c.SystemID {
+			continue
+		}
+		reply = append(reply, ZcashdRpcListcurrenciesEntry{
+			CurrencyDefinition: c,
+			BestHeight:         uint64(state.latestHeight),
+			BestCurrencyState:  c.BestCurrencyState,
+		})
+	}
+	return json.Marshal(reply)
+}
+
+// darksideIdentityMatches returns true if the given name (as passed to
+// getidentity) refers to the given identity.
+func darksideIdentityMatches(id *ZcashdRpcReplyGetidentity, name string) bool {
+	return name == id.Identity.IdentityAddress ||
+		strings.EqualFold(name, id.Identity.Name+"@") ||
+		(id.FullyQualifiedName != "" && strings.EqualFold(name, id.FullyQualifiedName))
+}
+
+// darksideIdentityParams parses the identity name and (optional) heights of
+// a getidentity or getidentityhistory request.
+func darksideIdentityParams(method string, params []json.RawMessage) (string, []int, error) {
+	var name string
+	if len(params) == 0 || json.Unmarshal(params[0], &name) != nil {
+		return "", nil, errors.New("failed to parse " + method + " request")
+	}
+	heights := make([]int, len(params)-1)
+	for i := range heights {
+		if err := json.Unmarshal(params[i+1], &heights[i]); err != nil {
+			return "", nil, errors.New("failed to parse " + method + " height")
+		}
+	}
+	return name, heights, nil
+}
+
+// Caller should hold state.mutex.RLock(). Only versions up to the given
+// height (and the presented tip) are visible, in height order.
+func darksideIdentityVersions(name string, start, end int) []*ZcashdRpcReplyGetidentity {
+	if end > state.latestHeight {
+		end = state.latestHeight
+	}
+	versions := make([]*ZcashdRpcReplyGetidentity, 0)
+	for i := range state.identities {
+		id := &state.identities[i]
+		if id.BlockHeight >= start && id.BlockHeight <= end && darksideIdentityMatches(id, name) {
+			versions = append(versions, id)
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].BlockHeight < versions[j].BlockHeight
+	})
+	return versions
+}
+
+func darksideGetIdentity(params []json.RawMessage) (json.RawMessage, error) {
+	name, heights, err := darksideIdentityParams("getidentity", params)
+	if err != nil {
+		return nil, err
+	}
+	state.mutex.RLock()
+	defer state.mutex.RUnlock()
+	end := state.latestHeight
+	if len(heights) > 0 && heights[0] > 0 {
+		end = heights[0]
+	}
+	versions := darksideIdentityVersions(name, 0, end)
+	if len(versions) == 0 {
+		return nil, errors.New("-5: Identity not found")
+	}
+	return json.Marshal(versions[len(versions)-1])
+}
+
+func darksideGetIdentityHistory(params []json.RawMessage) (json.RawMessage, error) {
+	name, heights, err := darksideIdentityParams("getidentityhistory", params)
+	if err != nil {
+		return nil, err
+	}
+	state.mutex.RLock()
+	defer state.mutex.RUnlock()
+	latest := darksideIdentityVersions(name, 0, state.latestHeight)
+	if len(latest) == 0 {
+		return nil, errors.New("-5: Identity not found")
+	}
+	start, end := 0, state.latestHeight
+	if len(heights) > 0 {
+		start = heights[0]
+	}
+	if len(heights) > 1 && heights[1] > 0 {
+		end = heights[1]
+	}
+	reply := ZcashdRpcReplyGetidentityhistory{
+		FullyQualifiedName: latest[len(latest)-1].FullyQualifiedName,
+		Status:             latest[len(latest)-1].Status,
+		History:            make([]ZcashdRpcIdentityHistoryEntry, 0),
+	}
+	for _, id := range darksideIdentityVersions(name, start, end) {
+		entry := ZcashdRpcIdentityHistoryEntry{
+			Identity: id.Identity,
+			Height:   id.BlockHeight,
+		}
+		entry.Output.Txid = id.Txid
+		entry.Output.VoutNum = id.Vout
+		if index := id.BlockHeight - state.startHeight; index >= 0 && index < len(state.activeBlocks) {
+			block := parser.NewBlock()
+			if _, err := block.ParseFromSlice(state.activeBlocks[index]); err == nil {
+				entry.BlockHash = hex.EncodeToString(block.GetDisplayHash())
+			}
+		}
+		reply.History = append(reply.History, entry)
+	}
+	return json.Marshal(reply)
+}
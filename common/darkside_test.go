@@ -0,0 +1,123 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/asherda/lightwalletd/parser"
+)
+
+func TestDarksideApplyStaged(t *testing.T) {
+	defer func() {
+		state = darksideState{}
+		ingestorRunning = false
+	}()
+	state = darksideState{resetted: true, startHeight: 1000, latestHeight: -1}
+	ingestorRunning = true // don't start the ingestor
+
+	if err := DarksideStageBlocksCreate(1000, 0, 2); err != nil {
+		t.Fatal("DarksideStageBlocksCreate failed:", err)
+	}
+	// Enough transactions to need a multi-byte transaction count
+	txBytes := parseTestBlock(t, 1).Transactions()[0].Bytes()
+	for i := 0; i < 300; i++ {
+		if err := DarksideStageTransaction(1000, txBytes); err != nil {
+			t.Fatal("DarksideStageTransaction failed:", err)
+		}
+	}
+	if err := DarksideApplyStaged(1001); err != nil {
+		t.Fatal("DarksideApplyStaged failed:", err)
+	}
+	if len(state.activeBlocks) != 2 || state.latestHeight != 1001 {
+		t.Fatal("unexpected active blocks ", len(state.activeBlocks), state.latestHeight)
+	}
+	var blocks []*parser.Block
+	for _, blockBytes := range state.activeBlocks {
+		block := parser.NewBlock()
+		if rest, err := block.ParseFromSlice(blockBytes); err != nil || len(rest) != 0 {
+			t.Fatal("can't parse active block:", err)
+		}
+		blocks = append(blocks, block)
+	}
+	if blocks[0].GetTxCount() != 301 || blocks[1].GetTxCount() != 1 {
+		t.Fatal("unexpected transaction counts ", blocks[0].GetTxCount(), blocks[1].GetTxCount())
+	}
+	// The merkle root covers the staged transactions, and the next block
+	// refers to the modified block.
+	reserialized, _ := blocks[0].MarshalBinary()
+	blocks[0].AppendTransactions()
+	if recomputed, _ := blocks[0].MarshalBinary(); !bytes.Equal(recomputed, reserialized) {
+		t.Fatal("unexpected merkle root")
+	}
+	if !bytes.Equal(blocks[1].GetPrevHash(), blocks[0].GetEncodableHash()) {
+		t.Fatal("unexpected prev hash")
+	}
+}
+
+func TestDarksideApplyStagedExisting(t *testing.T) {
+	defer func() {
+		state = darksideState{}
+		ingestorRunning = false
+	}()
+	state = darksideState{resetted: true, startHeight: 380640, latestHeight: -1}
+	ingestorRunning = true // don't start the ingestor
+
+	for i := 0; i < 2; i++ {
+		var blockHex string
+		json.Unmarshal(blocks[i], &blockHex)
+		if err := DarksideStageBlockStream(blockHex); err != nil {
+			t.Fatal("DarksideStageBlockStream failed:", err)
+		}
+	}
+	if err := DarksideApplyStaged(380641); err != nil {
+		t.Fatal("DarksideApplyStaged failed:", err)
+	}
+
+	// Add transactions to the (real) block that's already active.
+	original := parseTestBlock(t, 0)
+	staged := [][]byte{
+		parseTestBlock(t, 2).Transactions()[1].Bytes(),
+		parseTestBlock(t, 3).Transactions()[1].Bytes(),
+	}
+	for _, txBytes := range staged {
+		if err := DarksideStageTransaction(380640, txBytes); err != nil {
+			t.Fatal("DarksideStageTransaction failed:", err)
+		}
+	}
+	if err := DarksideApplyStaged(380641); err != nil {
+		t.Fatal("DarksideApplyStaged failed:", err)
+	}
+	block := parser.NewBlock()
+	if rest, err := block.ParseFromSlice(state.activeBlocks[0]); err != nil || len(rest) != 0 {
+		t.Fatal("can't parse modified block:", err)
+	}
+	if block.GetHeight() != 380640 || block.GetTxCount() != original.GetTxCount()+len(staged) {
+		t.Fatal("unexpected block ", block.GetHeight(), " transaction count ", block.GetTxCount())
+	}
+	// The block's transactions are intact, followed by the staged ones.
+	txs := block.Transactions()
+	for i, tx := range original.Transactions() {
+		if !bytes.Equal(txs[i].Bytes(), tx.Bytes()) {
+			t.Fatal("transaction ", i, " changed")
+		}
+	}
+	for i, txBytes := range staged {
+		if !bytes.Equal(txs[original.GetTxCount()+i].Bytes(), txBytes) {
+			t.Fatal("staged transaction ", i, " missing")
+		}
+	}
+	if err := block.CheckMerkleRoot(); err != nil {
+		t.Fatal("CheckMerkleRoot failed:", err)
+	}
+	next := parser.NewBlock()
+	if _, err := next.ParseFromSlice(state.activeBlocks[1]); err != nil {
+		t.Fatal("can't parse next block:", err)
+	}
+	if !bytes.Equal(next.GetPrevHash(), block.GetEncodableHash()) {
+		t.Fatal("unexpected prev hash")
+	}
+}
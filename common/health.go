@@ -0,0 +1,127 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxNodeOutage is how long zcashd may be unavailable to the block ingestor
+// before lightwalletd gives up and exits; zero means never.
+var MaxNodeOutage time.Duration
+
+// MaxSyncLag is how many blocks the cache may trail zcashd's best block by
+// while lightwalletd is still considered ready to serve wallets.
+var MaxSyncLag = 10
+
+// How often HealthWatcher checks readiness.
+const healthCheckInterval = 5 * time.Second
+
+// The health of zcashd, as seen by the block ingestor. While it's unhealthy,
+// gRPC handlers that need zcashd fail with Unavailable (but blocks that are in
+// the cache are still served).
+var nodeHealth struct {
+	mutex sync.RWMutex
+	err   error     // most recent error, or nil if zcashd is healthy
+	since time.Time // when zcashd became unhealthy
+}
+
+// setNodeError records that a request to zcashd failed, and returns how long
+// zcashd has been unavailable.
+func setNodeError(err error) time.Duration {
+	nodeHealth.mutex.Lock()
+	defer nodeHealth.mutex.Unlock()
+	if nodeHealth.err == nil {
+		nodeHealth.since = Time.Now()
+	}
+	nodeHealth.err = err
+	return Time.Now().Sub(nodeHealth.since)
+}
+
+// setNodeHealthy records that zcashd is answering requests.
+func setNodeHealthy() {
+	nodeHealth.mutex.Lock()
+	defer nodeHealth.mutex.Unlock()
+	if nodeHealth.err != nil {
+		Log.Info("zcashd is available again after ", Time.Now().Sub(nodeHealth.since))
+		nodeHealth.err = nil
+	}
+}
+
+// NodeError returns the most recent error from zcashd if it's unhealthy,
+// or nil if it's healthy.
+func NodeError() error {
+	nodeHealth.mutex.RLock()
+	defer nodeHealth.mutex.RUnlock()
+	return nodeHealth.err
+}
+
+// NodeUnavailable returns a gRPC Unavailable error if zcashd is unhealthy,
+// else nil. Handlers that need zcashd should check this first.
+func NodeUnavailable() error {
+	if err := NodeError(); err != nil {
+		return status.Errorf(codes.Unavailable, "zcashd is unavailable: %v", err)
+	}
+	return nil
+}
+
+// retryDelay returns how long to wait before retrying after the given number
+// of consecutive failures: exponentially longer, up to a minute, with jitter
+// so that instances sharing a zcashd don't retry in lockstep.
+func retryDelay(failures int) time.Duration {
+	delay := time.Minute
+	if failures < 7 {
+		delay = time.Second << uint(failures-1)
+	}
+	// somewhere in [delay/2, delay)
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
+
+// Readiness returns nil if lightwalletd is ready to serve wallets: zcashd is
+// reachable, and the cache is within MaxSyncLag blocks of its best block.
+// Otherwise it returns the reason it isn't.
+func Readiness(cache *BlockCache) error {
+	if err := NodeError(); err != nil {
+		return errors.Wrap(err, "zcashd is unavailable")
+	}
+	tip, err := getBlockCount()
+	if err != nil {
+		return errors.Wrap(err, "zcashd is unavailable")
+	}
+	latest := cache.GetLatestHeight()
+	if latest < 0 {
+		return errors.New("block cache is empty")
+	}
+	if tip-latest > MaxSyncLag {
+		return fmt.Errorf("block cache is %d blocks behind zcashd", tip-latest)
+	}
+	return nil
+}
+
+// HealthWatcher checks Readiness every few seconds (rep times, or forever if
+// rep is zero), passing each result to update.
+func HealthWatcher(cache *BlockCache, update func(notReady error), rep int) {
+	var last error
+	for i := 0; rep == 0 || i < rep; i++ {
+		err := Readiness(cache)
+		if i == 0 || (err == nil) != (last == nil) {
+			if err == nil {
+				Log.Info("ready to serve wallets")
+			} else {
+				Log.Warning("not ready to serve wallets: ", err)
+			}
+		}
+		update(err)
+		last = err
+		Time.Sleep(healthCheckInterval)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// cachedRequest is RawRequest for rpcs whose results depend only on the best
+// chain, so can be cached (in the block cache) until the next block or reorg.
+// Errors aren't cached.
+func cachedRequest(cache *BlockCache, method string, params []json.RawMessage) (json.RawMessage, error) {
+	key := requestKey(method, params)
+	result, hash := cache.GetResult(key)
+	if result != nil {
+		return result, nil
+	}
+	if err := NodeUnavailable(); err != nil {
+		return nil, err
+	}
+	result, err := RawRequest(method, params)
+	if err != nil {
+		return nil, err
+	}
+	cache.PutResult(key, result, hash)
+	return result, nil
+}
+
+// requestKey identifies an rpc request (method and parameters) in the cache.
+func requestKey(method string, params []json.RawMessage) string {
+	key := make([]string, 0, len(params)+1)
+	key = append(key, method)
+	for _, param := range params {
+		key = append(key, string(param))
+	}
+	return strings.Join(key, " ")
+}
+
+// GetIdentity returns the VerusID with the given name (such as "alice@") or
+// i-address, as of the given height, or the latest block if height is zero.
+func GetIdentity(cache *BlockCache, identity string, height uint64) (*ZcashdRpcReplyGetidentity, error) {
+	if identity == "" {
+		return nil, errors.New("identity must be specified")
+	}
+	params := make([]json.RawMessage, 1, 2)
+	params[0], _ = json.Marshal(identity)
+	if height > 0 {
+		heightJSON, _ := json.Marshal(height)
+		params = append(params, heightJSON)
+	}
+	result, err := cachedRequest(cache, "getidentity", params)
+	if err != nil {
+		return nil, err
+	}
+	var reply ZcashdRpcReplyGetidentity
+	if err := json.Unmarshal(result, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// GetIdentityHistory returns the versions of the VerusID with the given name
+// or i-address that were defined within the given range of block heights; an
+// end height of zero means up to the latest block.
+func GetIdentityHistory(cache *BlockCache, identity string, start, end uint64) (*ZcashdRpcReplyGetidentityhistory, error) {
+	if identity == "" {
+		return nil, errors.New("identity must be specified")
+	}
+	if end > 0 && end < start {
+		return nil, errors.New("end height is less than start height")
+	}
+	params := make([]json.RawMessage, 1, 3)
+	params[0], _ = json.Marshal(identity)
+	if start > 0 || end > 0 {
+		startJSON, _ := json.Marshal(start)
+		params = append(params, startJSON)
+	}
+	if end > 0 {
+		endJSON, _ := json.Marshal(end)
+		params = append(params, endJSON)
+	}
+	result, err := cachedRequest(cache, "getidentityhistory", params)
+	if err != nil {
+		return nil, err
+	}
+	var reply ZcashdRpcReplyGetidentityhistory
+	if err := json.Unmarshal(result, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
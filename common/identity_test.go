@@ -0,0 +1,195 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+)
+
+const testIdentityAddress = "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq"
+
+// Number of getidentity and getidentityhistory requests that reached zcashd
+var identityRequests int
+
+func identityStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	identityRequests++
+	var name string
+	json.Unmarshal(params[0], &name)
+	if name != "alice@" {
+		return nil, errors.New("-5: Identity not found")
+	}
+	switch method {
+	case "getidentity":
+		if len(params) > 1 && string(params[1]) != "380640" {
+			testT.Fatal("unexpected getidentity height ", string(params[1]))
+		}
+		return json.Marshal(ZcashdRpcReplyGetidentity{
+			FullyQualifiedName: "alice.VRSC@",
+			Identity: ZcashdRpcIdentity{
+				Name:             "alice",
+				IdentityAddress:  testIdentityAddress,
+				PrimaryAddresses: []string{"RCdXBieidGuXmK8Tw2gBoXWxi16UgqyKc7"},
+			},
+			Status:      "active",
+			BlockHeight: 380640,
+		})
+	case "getidentityhistory":
+		if len(params) != 3 || string(params[1]) != "0" || string(params[2]) != "380641" {
+			testT.Fatal("unexpected getidentityhistory params ", params)
+		}
+		reply := ZcashdRpcReplyGetidentityhistory{Status: "active"}
+		reply.History = make([]ZcashdRpcIdentityHistoryEntry, 2)
+		return json.Marshal(reply)
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestGetIdentity(t *testing.T) {
+	testT = t
+	RawRequest = identityStub
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+	defer func() {
+		identityRequests = 0
+		os.RemoveAll(unitTestPath)
+	}()
+	if err := testcache.Add(380640, parseTestBlock(t, 0).ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+
+	if _, err := GetIdentity(testcache, "", 0); err == nil {
+		t.Fatal("GetIdentity should have failed, no identity")
+	}
+	reply, err := GetIdentity(testcache, "alice@", 0)
+	if err != nil {
+		t.Fatal("GetIdentity failed:", err)
+	}
+	if reply.Identity.IdentityAddress != testIdentityAddress ||
+		len(reply.Identity.PrimaryAddresses) != 1 || reply.Status != "active" {
+		t.Fatal("unexpected GetIdentity reply ", reply)
+	}
+	// Until the next block, the result comes from the cache.
+	if _, err := GetIdentity(testcache, "alice@", 0); err != nil || identityRequests != 1 {
+		t.Fatal("GetIdentity should have been cached ", err, identityRequests)
+	}
+	// but a different height is a different request.
+	if _, err := GetIdentity(testcache, "alice@", 380640); err != nil || identityRequests != 2 {
+		t.Fatal("unexpected GetIdentity at height ", err, identityRequests)
+	}
+	// Errors aren't cached.
+	for i := 0; i < 2; i++ {
+		if _, err := GetIdentity(testcache, "bob@", 0); err == nil {
+			t.Fatal("GetIdentity should have failed, not found")
+		}
+	}
+	if identityRequests != 4 {
+		t.Fatal("unexpected number of requests ", identityRequests)
+	}
+
+	// A new block invalidates the cache, and so does a reorg.
+	if err := testcache.Add(380641, parseTestBlock(t, 1).ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+	GetIdentity(testcache, "alice@", 0)
+	GetIdentity(testcache, "alice@", 0)
+	if identityRequests != 5 {
+		t.Fatal("GetIdentity should have been refetched after a new block ", identityRequests)
+	}
+	testcache.Reorg(380641)
+	GetIdentity(testcache, "alice@", 0)
+	if identityRequests != 6 {
+		t.Fatal("GetIdentity should have been refetched after a reorg ", identityRequests)
+	}
+
+	// A result fetched across a new block isn't cached.
+	_, hash := testcache.GetResult("key")
+	testcache.Add(380641, parseTestBlock(t, 1).ToCompact())
+	testcache.PutResult("key", json.RawMessage("1"), hash)
+	if result, _ := testcache.GetResult("key"); result != nil {
+		t.Fatal("a stale result was cached")
+	}
+
+	history, err := GetIdentityHistory(testcache, "alice@", 0, 380641)
+	if err != nil {
+		t.Fatal("GetIdentityHistory failed:", err)
+	}
+	if len(history.History) != 2 {
+		t.Fatal("unexpected GetIdentityHistory reply ", history)
+	}
+	if _, err := GetIdentityHistory(testcache, "alice@", 380641, 380640); err == nil {
+		t.Fatal("GetIdentityHistory should have failed, bad range")
+	}
+}
+
+func TestDarksideIdentity(t *testing.T) {
+	defer func() {
+		state = darksideState{}
+	}()
+	state = darksideState{resetted: true, startHeight: 1000, latestHeight: 1001}
+	for _, h := range []int{1002, 1000, 1001} {
+		err := DarksideStageIdentity(ZcashdRpcReplyGetidentity{
+			FullyQualifiedName: "alice.VRSC@",
+			Identity: ZcashdRpcIdentity{
+				Name:            "alice",
+				IdentityAddress: testIdentityAddress,
+				Version:         uint32(h - 1000),
+			},
+			BlockHeight: h,
+		})
+		if err != nil {
+			t.Fatal("DarksideStageIdentity failed:", err)
+		}
+	}
+	if DarksideStageIdentity(ZcashdRpcReplyGetidentity{}) == nil {
+		t.Fatal("DarksideStageIdentity should have failed, no address")
+	}
+	getidentity := func(params ...interface{}) (*ZcashdRpcReplyGetidentity, error) {
+		var p []json.RawMessage
+		for _, param := range params {
+			j, _ := json.Marshal(param)
+			p = append(p, j)
+		}
+		result, err := darksideRawRequest("getidentity", p)
+		if err != nil {
+			return nil, err
+		}
+		var reply ZcashdRpcReplyGetidentity
+		return &reply, json.Unmarshal(result, &reply)
+	}
+
+	// The version at 1002 isn't visible yet.
+	for _, name := range []string{"alice@", "ALICE.vrsc@", testIdentityAddress} {
+		reply, err := getidentity(name)
+		if err != nil || reply.Identity.Version != 1 {
+			t.Fatal("unexpected getidentity ", name, reply, err)
+		}
+	}
+	if reply, err := getidentity("alice@", 1000); err != nil || reply.Identity.Version != 0 {
+		t.Fatal("unexpected getidentity at height ", reply, err)
+	}
+	if _, err := getidentity("bob@"); err == nil {
+		t.Fatal("getidentity should have failed, not found")
+	}
+
+	historyParams := []json.RawMessage{json.RawMessage(`"alice@"`), json.RawMessage("1001")}
+	result, err := darksideRawRequest("getidentityhistory", historyParams)
+	if err != nil {
+		t.Fatal("getidentityhistory failed:", err)
+	}
+	var history ZcashdRpcReplyGetidentityhistory
+	json.Unmarshal(result, &history)
+	if len(history.History) != 1 || history.History[0].Height != 1001 ||
+		history.FullyQualifiedName != "alice.VRSC@" {
+		t.Fatal("unexpected getidentityhistory reply ", history)
+	}
+
+	DarksideClearIdentities()
+	if _, err := getidentity("alice@"); err == nil {
+		t.Fatal("getidentity should have failed, cleared")
+	}
+}
@@ -1,27 +1,53 @@
 package common
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/asherda/lightwalletd/parser"
 	"github.com/asherda/lightwalletd/walletrpc"
 )
 
 type txid string
 
+// A mempoolEpoch is the mempool transactions that have arrived since a block,
+// in order received. It's only appended to, until the next block, when it
+// ends and a new epoch begins, so each subscriber can keep an index into
+// txs to record which transactions it's sent to its client (everything before
+// that index).
+type mempoolEpoch struct {
+	txs   []*walletrpc.RawTransaction // guarded by g_lock
+	ended chan struct{}               // closed when the epoch ends
+	err   error                       // why it ended, if not a new block
+}
+
+func newMempoolEpoch() *mempoolEpoch {
+	return &mempoolEpoch{ended: make(chan struct{})}
+}
+
+// A notifiedTx is a transaction from a rawtx notification.
+type notifiedTx struct {
+	data       []byte
+	generation int // g_mempoolGeneration when it arrived
+}
+
 var (
-	// Set of mempool txids that have been seen during the current block interval.
+	// Set of mempool txids that have been seen during the current epoch.
 	// The zcashd RPC `getrawmempool` returns the entire mempool each time, so
 	// this allows us to ignore the txids that we've already seen.
 	g_txidSeen map[txid]struct{} = map[txid]struct{}{}
 
-	// List of transactions during current block interval, in order received. Each
-	// client thread can keep an index into this slice to record which transactions
-	// it's sent back to the client (everything before that index). The g_txidSeen
-	// map allows this list to not contain duplicates.
-	g_txList []*walletrpc.RawTransaction
+	// Transactions from rawtx notifications, by txid, kept until the next
+	// getrawmempool so that those it lists needn't be fetched. (zcashd also
+	// notifies about the transactions in each new block, which it won't.)
+	g_notifiedTxs = map[txid]notifiedTx{}
+
+	// The current epoch.
+	g_epoch = newMempoolEpoch()
 
 	// The most recent absolute time that we fetched the mempool and the latest
 	// (tip) block hash (so we know when a new block has been mined).
@@ -31,66 +57,278 @@ var (
 	// hash (tip) which is used to detect when a new block arrives.
 	g_lastBlockChainInfo *ZcashdRpcReplyGetblockchaininfo = &ZcashdRpcReplyGetblockchaininfo{}
 
+	// Number of mempool subscribers (atomic); there's no need to keep the
+	// epoch up to date (from zmq notifications) if there are none.
+	g_clients int32
+
+	// The subscribers' channels, signalled (without blocking) whenever the
+	// current epoch changes.
+	g_subscribers = map[chan struct{}]struct{}{}
+
+	// Whether mempoolTracker is running (it exits when there are no
+	// subscribers).
+	g_trackerRunning bool
+
 	// Mutex to protect the above variables.
 	g_lock sync.Mutex
+
+	// How often mempoolTracker polls zcashd (unless zmq notifications are
+	// arriving).
+	mempoolPollInterval = 2 * time.Second
+
+	// Signalled to make mempoolTracker poll right away (for a new block or
+	// a stale snapshot), or notice it has no subscribers left.
+	mempoolPollChan = make(chan struct{}, 1)
 )
 
-func GetMempool(sendToClient func(*walletrpc.RawTransaction) error) error {
+// A mempoolSubscription is signalled on C whenever the mempool changes. A
+// signal is dropped if one is already pending, so a slow subscriber never
+// holds up the tracker; it catches up from the epoch's transactions.
+type mempoolSubscription struct {
+	C chan struct{}
+}
+
+func subscribeMempool() *mempoolSubscription {
+	sub := &mempoolSubscription{C: make(chan struct{}, 1)}
+	atomic.AddInt32(&g_clients, 1)
 	g_lock.Lock()
-	index := 0
-	// Stay in this function until the tip block hash changes.
-	stayHash := g_lastBlockChainInfo.BestBlockHash
+	defer g_lock.Unlock()
+	g_subscribers[sub.C] = struct{}{}
+	if !g_trackerRunning {
+		g_trackerRunning = true
+		go mempoolTracker()
+	}
+	return sub
+}
+
+// close ends the subscription; closing it again has no effect.
+func (sub *mempoolSubscription) close() {
+	g_lock.Lock()
+	defer g_lock.Unlock()
+	if _, ok := g_subscribers[sub.C]; !ok {
+		return
+	}
+	delete(g_subscribers, sub.C)
+	if len(g_subscribers) == 0 {
+		wakeMempoolTracker()
+	}
+	atomic.AddInt32(&g_clients, -1)
+}
+
+func wakeMempoolTracker() {
+	select {
+	case mempoolPollChan <- struct{}{}:
+	default:
+	}
+}
 
-	// Wait for more transactions to be added to the list
+// Caller should hold g_lock.
+func notifyMempoolSubscribers() {
+	for c := range g_subscribers {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// mempoolTracker runs as a goroutine, started by the first subscriber, and
+// polls zcashd for the mempool on behalf of all of them, however many there
+// are, until there are none.
+func mempoolTracker() {
 	for {
-		// Don't fetch the mempool more often than every 2 seconds.
-		now := Time.Now()
-		if now.After(g_lastTime.Add(2 * time.Second)) {
-			blockChainInfo, err := getLatestBlockChainInfo()
-			if err != nil {
-				g_lock.Unlock()
-				return err
-			}
-			if g_lastBlockChainInfo.BestBlockHash != blockChainInfo.BestBlockHash {
+		g_lock.Lock()
+		if len(g_subscribers) == 0 {
+			g_trackerRunning = false
+			// It will be out of date by the time anyone asks, so the next
+			// subscriber starts from a new epoch, after a fresh poll.
+			endMempoolEpoch(nil)
+			g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+			g_mempool = map[txid]*mempoolEntry{}
+			g_notifiedTxs = map[txid]notifiedTx{}
+			g_mempoolRemoved = nil
+			g_mempoolCache = nil
+			g_lock.Unlock()
+			return
+		}
+		g_lock.Unlock()
+		pollMempool()
+
+		// Don't fetch the mempool more often than every 2 seconds, or,
+		// while zmq notifications are arriving, much less often (but
+		// right away when one says there's a new block or transaction,
+		// and not while removed transactions are waiting for the cache to
+		// catch up).
+		g_lock.Lock()
+		waiting := len(g_mempoolRemoved) > 0
+		g_lock.Unlock()
+		interval := mempoolPollInterval
+		if zmqActive(zmqTopicHashBlock) && zmqActive(zmqTopicRawTx) && !waiting {
+			interval = zmqPollInterval
+		}
+		select {
+		case <-mempoolPollChan:
+		case <-time.After(interval):
+		}
+	}
+}
+
+// pollMempool brings the current epoch up to date with zcashd's mempool,
+// starting a new one if a block has arrived, and signals the subscribers.
+// An error ends the epoch (so its subscribers fail).
+func pollMempool() {
+	err := func() error {
+		blockChainInfo, err := getLatestBlockChainInfo()
+		if err != nil {
+			return err
+		}
+		g_lock.Lock()
+		if g_lastBlockChainInfo.BestBlockHash != blockChainInfo.BestBlockHash {
+			if g_lastBlockChainInfo.BestBlockHash != "" {
 				// A new block has arrived
-				g_lastBlockChainInfo = blockChainInfo
 				Log.Infoln("Latest Block changed, clearing everything")
-				// We're the first thread to notice, clear cached state.
-				g_txidSeen = map[txid]struct{}{}
-				g_txList = []*walletrpc.RawTransaction{}
-				g_lastTime = time.Time{}
-				break
-			}
-			if err = refreshMempoolTxns(); err != nil {
-				g_lock.Unlock()
-				return err
+				endMempoolEpoch(nil)
 			}
-			g_lastTime = now
+			g_lastBlockChainInfo = blockChainInfo
 		}
+		g_lock.Unlock()
+		return refreshMempoolTxns()
+	}()
+
+	g_lock.Lock()
+	defer g_lock.Unlock()
+	if err != nil {
+		Log.Warning("error polling the mempool: ", err)
+		endMempoolEpoch(err)
+		g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+		return
+	}
+	g_lastTime = Time.Now()
+	notifyMempoolSubscribers()
+}
+
+// endMempoolEpoch replaces the current epoch. Caller should hold g_lock.
+func endMempoolEpoch(err error) {
+	g_epoch.err = err
+	close(g_epoch.ended)
+	g_epoch = newMempoolEpoch()
+	g_txidSeen = map[txid]struct{}{}
+	g_lastTime = time.Time{}
+	notifyMempoolSubscribers()
+}
+
+// GetMempool sends the mempool transactions that have arrived since the
+// latest block, then each new one as it arrives, until the next block.
+func GetMempool(sendToClient func(*walletrpc.RawTransaction) error) error {
+	sub := subscribeMempool()
+	defer sub.close()
+	g_lock.Lock()
+	epoch := g_epoch
+	g_lock.Unlock()
+	index := 0
+	ended := false
+	for {
 		// Send transactions we haven't sent yet, best to not do so while
 		// holding the mutex, since this call may get flow-controlled.
-		toSend := g_txList[index:]
-		index = len(g_txList)
+		g_lock.Lock()
+		toSend := epoch.txs[index:]
+		index = len(epoch.txs)
 		g_lock.Unlock()
 		for _, tx := range toSend {
 			if err := sendToClient(tx); err != nil {
 				return err
 			}
 		}
-		Time.Sleep(200 * time.Millisecond)
+		if ended {
+			return epoch.err
+		}
+		select {
+		case <-sub.C:
+		case <-epoch.ended:
+			// (send any transactions that arrived just before)
+			ended = true
+		}
+	}
+}
+
+// MempoolSnapshot returns the mempool transactions that have arrived since
+// the latest block, having the tracker refresh the list if it hasn't been
+// recently.
+func MempoolSnapshot() ([]*walletrpc.RawTransaction, error) {
+	sub := subscribeMempool()
+	defer sub.close()
+	for {
 		g_lock.Lock()
-		if g_lastBlockChainInfo.BestBlockHash != stayHash {
-			break
+		epoch := g_epoch
+		if !g_lastTime.IsZero() && Time.Now().Before(g_lastTime.Add(2*time.Second)) {
+			txs := append([]*walletrpc.RawTransaction(nil), epoch.txs...)
+			g_lock.Unlock()
+			return txs, nil
+		}
+		g_lock.Unlock()
+		wakeMempoolTracker()
+		select {
+		case <-sub.C:
+		case <-epoch.ended:
+			if epoch.err != nil {
+				return nil, epoch.err
+			}
 		}
 	}
-	g_lock.Unlock()
-	return nil
 }
 
-// RefreshMempoolTxns gets all new mempool txns and sends any new ones to waiting clients
+// MempoolTransactions returns the (parsed) mempool transactions, as from
+// MempoolSnapshot, except those whose txids (little-endian, as in
+// CompactTx.hash) start with one of the exclude prefixes. A prefix that
+// matches more than one of them excludes none, since the client can't know
+// which one it has.
+func MempoolTransactions(exclude [][]byte) ([]*parser.Transaction, error) {
+	mempool, err := MempoolSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*parser.Transaction, 0, len(mempool))
+	for _, rtx := range mempool {
+		tx := parser.NewTransaction()
+		if rest, err := tx.ParseFromSlice(rtx.Data); err != nil || len(rest) != 0 {
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	excluded := make(map[*parser.Transaction]bool)
+	for _, prefix := range exclude {
+		var match *parser.Transaction
+		matches := 0
+		for _, tx := range txs {
+			if bytes.HasPrefix(tx.GetEncodableHash(), prefix) {
+				match = tx
+				matches++
+			}
+		}
+		if matches == 1 {
+			excluded[match] = true
+		}
+	}
+	included := txs[:0]
+	for _, tx := range txs {
+		if !excluded[tx] {
+			included = append(included, tx)
+		}
+	}
+	return included, nil
+}
+
+// refreshMempoolTxns fetches the mempool transactions that aren't in the
+// current epoch yet (unless a rawtx notification brought them), and appends
+// them to it, then notices the ones that have left the mempool. The zcashd
+// RPCs are made without holding g_lock.
 func refreshMempoolTxns() error {
 	Log.Infoln("Refreshing mempool")
 
+	g_lock.Lock()
+	g_mempoolGeneration++
+	generation := g_mempoolGeneration
+	g_lock.Unlock()
 	params := []json.RawMessage{}
 	result, rpcErr := RawRequest("getrawmempool", params)
 	if rpcErr != nil {
@@ -102,14 +340,19 @@ func refreshMempoolTxns() error {
 		return err
 	}
 
-	// Fetch all new mempool txns and add them into `newTxns`
+	// Fetch all new mempool txns and append them to the epoch
 	for _, txidstr := range mempoolList {
-		if _, ok := g_txidSeen[txid(txidstr)]; ok {
+		g_lock.Lock()
+		_, seen := g_txidSeen[txid(txidstr)]
+		notified, ok := g_notifiedTxs[txid(txidstr)]
+		if !seen && ok {
+			appendMempoolTx(txid(txidstr), notified.data)
+		}
+		g_lock.Unlock()
+		if seen || ok {
 			// We've already fetched this transaction
 			continue
 		}
-		g_txidSeen[txid(txidstr)] = struct{}{}
-		// We haven't fetched this transaction already.
 		txidJSON, err := json.Marshal(txidstr)
 		if err != nil {
 			return err
@@ -132,16 +375,37 @@ func refreshMempoolTxns() error {
 		if err != nil {
 			return err
 		}
-		Log.Infoln("appending", txidstr)
-		newRtx := &walletrpc.RawTransaction{
-			Data:   txBytes,
-			Height: uint64(g_lastBlockChainInfo.Blocks),
+		g_lock.Lock()
+		appendMempoolTx(txid(txidstr), txBytes)
+		g_lock.Unlock()
+	}
+	g_lock.Lock()
+	for txidstr, notified := range g_notifiedTxs {
+		// Those that arrived since getrawmempool may be listed next time.
+		if notified.generation < generation {
+			delete(g_notifiedTxs, txidstr)
 		}
-		g_txList = append(g_txList, newRtx)
 	}
+	untrackMempoolTxs(mempoolList, generation)
+	g_lock.Unlock()
 	return nil
 }
 
+// appendMempoolTx adds the transaction to the current epoch, if it's not
+// already there. Caller should hold g_lock.
+func appendMempoolTx(txidstr txid, txBytes []byte) {
+	if _, ok := g_txidSeen[txidstr]; ok {
+		return
+	}
+	g_txidSeen[txidstr] = struct{}{}
+	Log.Infoln("appending", txidstr)
+	g_epoch.txs = append(g_epoch.txs, &walletrpc.RawTransaction{
+		Data:   txBytes,
+		Height: uint64(g_lastBlockChainInfo.Blocks),
+	})
+	trackMempoolTx(txidstr, txBytes)
+}
+
 func getLatestBlockChainInfo() (*ZcashdRpcReplyGetblockchaininfo, error) {
 	result, rpcErr := RawRequest("getblockchaininfo", []json.RawMessage{})
 	if rpcErr != nil {
@@ -0,0 +1,174 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/asherda/lightwalletd/parser"
+)
+
+// The mempool, by txid (big-endian hex, as from zcashd)
+var mempoolTxs map[string]*parser.Transaction
+
+func mempoolTxsStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	switch method {
+	case "getblockchaininfo":
+		return []byte(`{"blocks": 380643, "bestblockhash": "0102"}`), nil
+	case "getrawmempool":
+		var txids []string
+		for txid := range mempoolTxs {
+			txids = append(txids, txid)
+		}
+		return json.Marshal(txids)
+	case "getrawtransaction":
+		var txid string
+		json.Unmarshal(params[0], &txid)
+		return json.Marshal(hex.EncodeToString(mempoolTxs[txid].Bytes()))
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestMempoolTransactions(t *testing.T) {
+	testT = t
+	waitMempoolIdle(t)
+	RawRequest = mempoolTxsStub
+	Time.Now = time.Now
+	g_lastTime = time.Time{}
+	g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+	defer func() {
+		g_txidSeen = map[txid]struct{}{}
+		g_epoch = newMempoolEpoch()
+		g_lastTime = time.Time{}
+	}()
+	mempoolTxs = make(map[string]*parser.Transaction)
+	for i := 2; i < 4; i++ {
+		for _, tx := range parseTestBlock(t, i).Transactions() {
+			mempoolTxs[hex.EncodeToString(tx.GetDisplayHash())] = tx
+		}
+	}
+	var hashes [][]byte
+	for _, tx := range mempoolTxs {
+		hashes = append(hashes, tx.GetEncodableHash())
+	}
+
+	txs, err := MempoolTransactions(nil)
+	if err != nil || len(txs) != 4 {
+		t.Fatal("MempoolTransactions failed ", len(txs), err)
+	}
+	// A full txid and a (unique) prefix exclude transactions; an ambiguous
+	// prefix doesn't.
+	txs, err = MempoolTransactions([][]byte{hashes[0], hashes[1][:4], {}})
+	if err != nil || len(txs) != 2 {
+		t.Fatal("MempoolTransactions failed ", len(txs), err)
+	}
+	for _, tx := range txs {
+		if bytes.Equal(tx.GetEncodableHash(), hashes[0]) || bytes.Equal(tx.GetEncodableHash(), hashes[1]) {
+			t.Fatal("transaction not excluded")
+		}
+	}
+}
+
+func TestMempoolNotifiedTxs(t *testing.T) {
+	testT = t
+	waitMempoolIdle(t)
+	RawRequest = func(method string, params []json.RawMessage) (json.RawMessage, error) {
+		if method == "getrawtransaction" {
+			t.Fatal("notified transaction fetched")
+		}
+		return mempoolTxsStub(method, params)
+	}
+	g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+	defer func() {
+		g_txidSeen = map[txid]struct{}{}
+		g_notifiedTxs = map[txid]notifiedTx{}
+		g_mempool = map[txid]*mempoolEntry{}
+		g_epoch = newMempoolEpoch()
+	}()
+	inMempool := parseTestBlock(t, 2).Transactions()[1]
+	inBlock := parseTestBlock(t, 3).Transactions()[1]
+	mempoolTxs = map[string]*parser.Transaction{
+		hex.EncodeToString(inMempool.GetDisplayHash()): inMempool,
+	}
+	// Notified before the next getrawmempool: kept only if it lists them.
+	for _, tx := range []*parser.Transaction{inMempool, inBlock} {
+		g_notifiedTxs[txid(hex.EncodeToString(tx.GetDisplayHash()))] = notifiedTx{
+			data: tx.Bytes(), generation: g_mempoolGeneration,
+		}
+	}
+	if err := refreshMempoolTxns(); err != nil {
+		t.Fatal("refreshMempoolTxns failed ", err)
+	}
+	if len(g_epoch.txs) != 1 || !bytes.Equal(g_epoch.txs[0].Data, inMempool.Bytes()) {
+		t.Fatal("unexpected mempool transactions ", len(g_epoch.txs))
+	}
+	if len(g_notifiedTxs) != 0 {
+		t.Fatal("unexpected notified transactions ", len(g_notifiedTxs))
+	}
+
+	// One notified after getrawmempool may be listed by the next.
+	g_notifiedTxs[txid(hex.EncodeToString(inBlock.GetDisplayHash()))] = notifiedTx{
+		data: inBlock.Bytes(), generation: g_mempoolGeneration + 1,
+	}
+	refreshMempoolTxns()
+	if len(g_notifiedTxs) != 1 {
+		t.Fatal("notified transaction dropped too soon")
+	}
+	refreshMempoolTxns()
+	if len(g_notifiedTxs) != 0 {
+		t.Fatal("unexpected notified transactions ", len(g_notifiedTxs))
+	}
+}
+
+func TestMempoolTrackerExit(t *testing.T) {
+	testT = t
+	waitMempoolIdle(t)
+	RawRequest = mempoolTxsStub
+	Time.Now = time.Now
+	g_lastTime = time.Time{}
+	g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+	mempoolTxs = make(map[string]*parser.Transaction)
+	for _, tx := range parseTestBlock(t, 2).Transactions() {
+		mempoolTxs[hex.EncodeToString(tx.GetDisplayHash())] = tx
+	}
+	if txs, err := MempoolTransactions(nil); err != nil || len(txs) != 2 {
+		t.Fatal("MempoolTransactions failed ", len(txs), err)
+	}
+	g_lock.Lock()
+	epoch := g_epoch
+	g_lock.Unlock()
+
+	// Once the last subscriber leaves, nothing from that epoch remains.
+	waitMempoolIdle(t)
+	select {
+	case <-epoch.ended:
+	default:
+		t.Fatal("epoch didn't end")
+	}
+	if len(g_epoch.txs) != 0 || len(g_txidSeen) != 0 || !g_lastTime.IsZero() ||
+		g_lastBlockChainInfo.BestBlockHash != "" {
+		t.Fatal("mempool state left over")
+	}
+
+	// So the next subscriber gets only what's in the mempool now.
+	mempoolTxs = make(map[string]*parser.Transaction)
+	for _, tx := range parseTestBlock(t, 3).Transactions() {
+		mempoolTxs[hex.EncodeToString(tx.GetDisplayHash())] = tx
+	}
+	txs, err := MempoolTransactions(nil)
+	if err != nil || len(txs) != 2 {
+		t.Fatal("MempoolTransactions failed ", len(txs), err)
+	}
+	for _, tx := range txs {
+		if mempoolTxs[hex.EncodeToString(tx.GetDisplayHash())] == nil {
+			t.Fatal("transaction from a previous epoch returned")
+		}
+	}
+	waitMempoolIdle(t)
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/walletrpc"
+)
+
+// mempoolEventBuffer is how many events a mempool event subscriber may fall
+// behind by before it's dropped.
+const mempoolEventBuffer = 1000
+
+// A mempoolEntry is a transaction the tracker has seen in the mempool.
+type mempoolEntry struct {
+	ctx        *walletrpc.CompactTx // ctx.Hash is the (little-endian) txid
+	expiry     int                  // nExpiryHeight
+	spent      [][]byte             // outpoints and nullifiers
+	seq        int                  // the order it was added in
+	generation int                  // g_mempoolGeneration when it was added
+	height     int                  // the latest block height when it was added
+	removed    int                  // the latest block height when it had left
+}
+
+var (
+	// The transactions in zcashd's mempool, as of the tracker's latest poll
+	// (and zmq notifications since). Unlike the epoch, this outlives blocks,
+	// so transactions that leave the mempool can be noticed; it's forgotten
+	// when the tracker exits.
+	g_mempool    = map[txid]*mempoolEntry{}
+	g_mempoolSeq int
+
+	// Incremented at each getrawmempool, so a transaction that arrives
+	// (by zmq) after it isn't taken to have been removed.
+	g_mempoolGeneration int
+
+	g_eventSubscribers = map[*MempoolEventSubscription]struct{}{}
+
+	// The transactions that have left the mempool, in the order they were
+	// added, waiting for the cache to have the blocks up to the height at
+	// which they were noticed to have left (so whether they were mined, or a
+	// conflicting transaction was, can be told).
+	g_mempoolRemoved []*mempoolEntry
+
+	// The cache of the blocks the removed transactions may have been mined
+	// in, as given by the event subscribers; if nil, a transaction that's
+	// left the mempool can't be told to have been mined.
+	g_mempoolCache *BlockCache
+)
+
+// MempoolEventSubscription delivers the changes to the mempool on C. C is
+// closed when the subscription is closed, or if the subscriber falls more than
+// mempoolEventBuffer events behind (it should then resubscribe).
+type MempoolEventSubscription struct {
+	C       <-chan *walletrpc.MempoolEvent
+	c       chan *walletrpc.MempoolEvent
+	mempool *mempoolSubscription // keeps the tracker running
+}
+
+// SubscribeMempoolEvents returns a subscription to the changes to the mempool
+// and, as ADDED events, the transactions in it as of subscribing. The cache's
+// blocks tell which transactions that leave the mempool were mined.
+func SubscribeMempoolEvents(cache *BlockCache) (*MempoolEventSubscription, []*walletrpc.MempoolEvent) {
+	c := make(chan *walletrpc.MempoolEvent, mempoolEventBuffer)
+	sub := &MempoolEventSubscription{C: c, c: c, mempool: subscribeMempool()}
+	g_lock.Lock()
+	defer g_lock.Unlock()
+	g_mempoolCache = cache
+	g_eventSubscribers[sub] = struct{}{}
+	entries := make([]*mempoolEntry, 0, len(g_mempool))
+	for _, entry := range g_mempool {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+	current := make([]*walletrpc.MempoolEvent, len(entries))
+	for i, entry := range entries {
+		current[i] = &walletrpc.MempoolEvent{
+			Type:   walletrpc.MempoolEvent_ADDED,
+			Txid:   entry.ctx.Hash,
+			Tx:     entry.ctx,
+			Height: uint64(g_lastBlockChainInfo.Blocks),
+		}
+	}
+	return sub, current
+}
+
+// Close ends the subscription (and closes C); closing it again has no
+// effect.
+func (sub *MempoolEventSubscription) Close() {
+	g_lock.Lock()
+	removeMempoolEventSubscriber(sub)
+	g_lock.Unlock()
+	sub.mempool.close()
+}
+
+// Caller should hold g_lock.
+func removeMempoolEventSubscriber(sub *MempoolEventSubscription) {
+	if _, ok := g_eventSubscribers[sub]; ok {
+		delete(g_eventSubscribers, sub)
+		close(sub.c)
+	}
+}
+
+// Caller should hold g_lock.
+func publishMempoolEvent(event *walletrpc.MempoolEvent) {
+	for sub := range g_eventSubscribers {
+		select {
+		case sub.c <- event:
+		default:
+			Log.Warning("dropping a mempool event subscriber that fell too far behind")
+			removeMempoolEventSubscriber(sub)
+		}
+	}
+}
+
+// trackMempoolTx records a transaction that's arrived in the mempool, if it's
+// new (not just new to this epoch). Caller should hold g_lock.
+func trackMempoolTx(txidstr txid, txBytes []byte) {
+	if _, ok := g_mempool[txidstr]; ok {
+		return
+	}
+	tx := parser.NewTransaction()
+	if rest, err := tx.ParseFromSlice(txBytes); err != nil || len(rest) != 0 {
+		return
+	}
+	g_mempoolSeq++
+	entry := &mempoolEntry{
+		ctx:        tx.ToCompact(0),
+		expiry:     tx.ExpiryHeight(),
+		spent:      tx.Spent(),
+		seq:        g_mempoolSeq,
+		generation: g_mempoolGeneration,
+		height:     g_lastBlockChainInfo.Blocks,
+	}
+	g_mempool[txidstr] = entry
+	publishMempoolEvent(&walletrpc.MempoolEvent{
+		Type:   walletrpc.MempoolEvent_ADDED,
+		Txid:   entry.ctx.Hash,
+		Tx:     entry.ctx,
+		Height: uint64(g_lastBlockChainInfo.Blocks),
+	})
+}
+
+// untrackMempoolTxs compares the tracked transactions (from before the given
+// generation) with the mempool zcashd returned, and removes those that have
+// left it, then tells the subscribers why they have, as far as it can.
+// Caller should hold g_lock.
+func untrackMempoolTxs(mempoolList []string, generation int) {
+	inMempool := make(map[txid]bool, len(mempoolList))
+	for _, txidstr := range mempoolList {
+		inMempool[txid(txidstr)] = true
+	}
+	var removed []*mempoolEntry
+	for txidstr, entry := range g_mempool {
+		if entry.generation < generation && !inMempool[txidstr] {
+			entry.removed = g_lastBlockChainInfo.Blocks
+			removed = append(removed, entry)
+			delete(g_mempool, txidstr)
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].seq < removed[j].seq })
+	g_mempoolRemoved = append(g_mempoolRemoved, removed...)
+	publishMempoolRemovals()
+}
+
+// publishMempoolRemovals tells the subscribers why the transactions that have
+// left the mempool did, once the cache has the blocks up to the height at
+// which each was noticed to: it was mined, or a conflicting transaction (one
+// that spends one of the same outpoints or nullifiers) was, or it expired, or
+// a conflicting transaction is in the mempool instead, or else (it was
+// evicted) just that it's gone. Caller should hold g_lock.
+func publishMempoolRemovals() {
+	cacheHeight := -1
+	if g_mempoolCache != nil {
+		cacheHeight = g_mempoolCache.GetLatestHeight()
+	}
+	var ready, waiting []*mempoolEntry
+	for _, entry := range g_mempoolRemoved {
+		if g_mempoolCache == nil || entry.removed <= cacheHeight {
+			ready = append(ready, entry)
+		} else {
+			waiting = append(waiting, entry)
+		}
+	}
+	g_mempoolRemoved = waiting
+	if len(ready) == 0 {
+		return
+	}
+
+	// The transactions in, and what's spent by, the blocks mined while the
+	// ready transactions were in the mempool (Sprout nullifiers aren't in
+	// the compact blocks)
+	mined := make(map[string]bool)
+	minedSpenders := make(map[string][]byte)
+	if g_mempoolCache != nil {
+		from, to := ready[0].height, ready[0].removed
+		for _, entry := range ready {
+			from = min(from, entry.height)
+			to = max(to, entry.removed)
+		}
+		for height := from + 1; height <= to; height++ {
+			block := g_mempoolCache.Get(height)
+			if block == nil {
+				continue
+			}
+			for _, ctx := range block.Vtx {
+				mined[string(ctx.Hash)] = true
+				for _, in := range ctx.Vin {
+					outpoint := make([]byte, 36)
+					copy(outpoint, in.PrevoutTxid)
+					binary.LittleEndian.PutUint32(outpoint[32:], in.PrevoutIndex)
+					minedSpenders[string(outpoint)] = ctx.Hash
+				}
+				for _, spend := range ctx.Spends {
+					minedSpenders[string(spend.Nf)] = ctx.Hash
+				}
+			}
+		}
+	}
+	// What the remaining transactions spend
+	spenders := make(map[string][]byte)
+	for _, entry := range g_mempool {
+		for _, spent := range entry.spent {
+			spenders[string(spent)] = entry.ctx.Hash
+		}
+	}
+
+	for _, entry := range ready {
+		event := &walletrpc.MempoolEvent{
+			Type:   walletrpc.MempoolEvent_REMOVED,
+			Txid:   entry.ctx.Hash,
+			Height: uint64(entry.removed),
+		}
+		if mined[string(entry.ctx.Hash)] {
+			event.Type = walletrpc.MempoolEvent_MINED
+		} else if conflicting := conflictingTxid(entry, minedSpenders); conflicting != nil {
+			event.Type = walletrpc.MempoolEvent_CONFLICTED
+			event.ConflictingTxid = conflicting
+		} else if entry.expiry != 0 && entry.removed > entry.expiry {
+			// As zcashd's IsExpiredTx
+			event.Type = walletrpc.MempoolEvent_EXPIRED
+		} else if conflicting := conflictingTxid(entry, spenders); conflicting != nil {
+			event.Type = walletrpc.MempoolEvent_CONFLICTED
+			event.ConflictingTxid = conflicting
+		}
+		publishMempoolEvent(event)
+	}
+}
+
+// conflictingTxid returns the txid of the transaction that spends one of the
+// same outpoints or nullifiers as the entry, according to spenders, or nil.
+func conflictingTxid(entry *mempoolEntry, spenders map[string][]byte) []byte {
+	for _, spent := range entry.spent {
+		if spender, ok := spenders[string(spent)]; ok {
+			return spender
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/walletrpc"
+)
+
+// zcashd's height and mempool (txids, big-endian hex, in order), and the
+// transactions by txid
+var (
+	mempoolEventsHeight int
+	mempoolEventsList   []string
+	mempoolEventsTxs    map[string][]byte
+)
+
+func mempoolEventsStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	switch method {
+	case "getblockchaininfo":
+		return json.Marshal(&ZcashdRpcReplyGetblockchaininfo{
+			BestBlockHash: strconv.Itoa(mempoolEventsHeight),
+			Blocks:        mempoolEventsHeight,
+		})
+	case "getrawmempool":
+		return json.Marshal(mempoolEventsList)
+	case "getrawtransaction":
+		var txid string
+		json.Unmarshal(params[0], &txid)
+		return json.Marshal(hex.EncodeToString(mempoolEventsTxs[txid]))
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestMempoolEvents(t *testing.T) {
+	testT = t
+	waitMempoolIdle(t)
+	RawRequest = mempoolEventsStub
+	Time.Now = time.Now
+	g_lastTime = time.Time{}
+	g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+	// Polls happen only when the test asks.
+	mempoolPollInterval = time.Hour
+	defer func() {
+		mempoolPollInterval = 2 * time.Second
+		g_txidSeen = map[txid]struct{}{}
+		g_epoch = newMempoolEpoch()
+		g_lastTime = time.Time{}
+		g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+	}()
+
+	// Transactions that expire at 380660 and 380662, and one that spends the
+	// same outpoint as the second (it has a different nLockTime).
+	txA := parseTestBlock(t, 2).Transactions()[1]
+	txB := parseTestBlock(t, 3).Transactions()[1]
+	dataC := append([]byte(nil), txB.Bytes()...)
+	dataC[len(dataC)-9]++
+	txC := parser.NewTransaction()
+	if _, err := txC.ParseFromSlice(dataC); err != nil {
+		t.Fatal("can't parse conflicting transaction ", err)
+	}
+	mempoolEventsTxs = make(map[string][]byte)
+	txid := func(tx *parser.Transaction) string {
+		id := hex.EncodeToString(tx.GetDisplayHash())
+		mempoolEventsTxs[id] = tx.Bytes()
+		return id
+	}
+	mempoolEventsHeight = 380650
+	mempoolEventsList = []string{txid(txA), txid(txB)}
+	txid(txC)
+
+	expect := func(sub *MempoolEventSubscription, eventType walletrpc.MempoolEvent_Type, tx, conflicting *parser.Transaction) {
+		var event *walletrpc.MempoolEvent
+		select {
+		case event = <-sub.C:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event, expecting ", eventType)
+		}
+		if event.Type != eventType || !bytes.Equal(event.Txid, tx.GetEncodableHash()) ||
+			event.Height != uint64(mempoolEventsHeight) {
+			t.Fatal("unexpected event ", event)
+		}
+		if eventType == walletrpc.MempoolEvent_ADDED &&
+			(event.Tx == nil || !bytes.Equal(event.Tx.Hash, event.Txid) || len(event.Tx.Vin) != 1) {
+			t.Fatal("unexpected added transaction ", event.Tx)
+		}
+		if conflicting != nil && !bytes.Equal(event.ConflictingTxid, conflicting.GetEncodableHash()) {
+			t.Fatal("unexpected conflicting txid ", event.ConflictingTxid)
+		}
+	}
+
+	// The first subscriber starts the tracker, which polls right away.
+	// Without a cache, a transaction can't be told to have been mined.
+	sub, current := SubscribeMempoolEvents(nil)
+	defer sub.Close()
+	if len(current) != 0 {
+		t.Fatal("unexpected current mempool ", current)
+	}
+	expect(sub, walletrpc.MempoolEvent_ADDED, txA, nil)
+	expect(sub, walletrpc.MempoolEvent_ADDED, txB, nil)
+
+	// A later subscriber gets the mempool as ADDED events.
+	later, current := SubscribeMempoolEvents(nil)
+	later.Close()
+	if len(current) != 2 || current[0].Type != walletrpc.MempoolEvent_ADDED ||
+		!bytes.Equal(current[0].Txid, txA.GetEncodableHash()) || !bytes.Equal(current[1].Txid, txB.GetEncodableHash()) {
+		t.Fatal("unexpected current mempool ", current)
+	}
+	if _, ok := <-later.C; ok {
+		t.Fatal("subscription not closed")
+	}
+
+	// The first is gone, the second is replaced by a double spend.
+	mempoolEventsList = []string{txid(txC)}
+	mempoolPollChan <- struct{}{}
+	expect(sub, walletrpc.MempoolEvent_ADDED, txC, nil)
+	expect(sub, walletrpc.MempoolEvent_REMOVED, txA, nil)
+	expect(sub, walletrpc.MempoolEvent_CONFLICTED, txB, txC)
+
+	// The replacement expires (it was still valid at its expiry height).
+	mempoolEventsHeight = 380663
+	mempoolEventsList = []string{}
+	mempoolPollChan <- struct{}{}
+	expect(sub, walletrpc.MempoolEvent_EXPIRED, txC, nil)
+
+	// The tracker forgets the mempool when no one's interested.
+	sub.Close()
+	waitMempoolIdle(t)
+	if len(g_mempool) != 0 {
+		t.Fatal("unexpected tracked transactions ", len(g_mempool))
+	}
+}
+
+func TestMempoolEventSubscriberDropped(t *testing.T) {
+	defer func() {
+		g_eventSubscribers = map[*MempoolEventSubscription]struct{}{}
+	}()
+	c := make(chan *walletrpc.MempoolEvent, mempoolEventBuffer)
+	slow := &MempoolEventSubscription{C: c, c: c}
+	g_lock.Lock()
+	g_eventSubscribers[slow] = struct{}{}
+	for i := 0; i <= mempoolEventBuffer; i++ {
+		publishMempoolEvent(&walletrpc.MempoolEvent{Type: walletrpc.MempoolEvent_REMOVED})
+	}
+	g_lock.Unlock()
+	count := 0
+	for range slow.C {
+		count++
+	}
+	if count != mempoolEventBuffer {
+		t.Fatal("unexpected event count ", count)
+	}
+}
+
+func TestMempoolRemovals(t *testing.T) {
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+	c := make(chan *walletrpc.MempoolEvent, mempoolEventBuffer)
+	sub := &MempoolEventSubscription{C: c, c: c}
+	g_lock.Lock()
+	defer func() {
+		g_eventSubscribers = map[*MempoolEventSubscription]struct{}{}
+		g_mempool = map[txid]*mempoolEntry{}
+		g_mempoolRemoved = nil
+		g_mempoolCache = nil
+		g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+		g_lock.Unlock()
+		os.RemoveAll(unitTestPath)
+	}()
+	g_eventSubscribers[sub] = struct{}{}
+	g_mempoolCache = testcache
+	for i := 0; i < 2; i++ {
+		if err := testcache.Add(380640+i, parseTestBlock(t, i).ToCompact()); err != nil {
+			t.Fatal("cache.Add failed:", err)
+		}
+	}
+
+	// A is mined in the third test block, B in the fourth, and C spends the
+	// same outpoint as B; D and E spend other outpoints (of A's transaction)
+	// and expire at 380643.
+	variant := func(tx *parser.Transaction, change func([]byte)) *parser.Transaction {
+		data := append([]byte(nil), tx.Bytes()...)
+		change(data)
+		variant := parser.NewTransaction()
+		if _, err := variant.ParseFromSlice(data); err != nil {
+			t.Fatal("can't parse transaction ", err)
+		}
+		return variant
+	}
+	expiring := func(index byte) func([]byte) {
+		return func(data []byte) {
+			data[41] += index // the prevout index
+			binary.LittleEndian.PutUint32(data[len(data)-5:], 380643)
+		}
+	}
+	txA := parseTestBlock(t, 2).Transactions()[1]
+	txB := parseTestBlock(t, 3).Transactions()[1]
+	txC := variant(txB, func(data []byte) { data[len(data)-9]++ })
+	txD := variant(txA, expiring(1))
+	txE := variant(txA, expiring(2))
+	if txD.ExpiryHeight() != 380643 {
+		t.Fatal("unexpected expiry height ", txD.ExpiryHeight())
+	}
+	txidstr := func(tx *parser.Transaction) string {
+		return hex.EncodeToString(tx.GetDisplayHash())
+	}
+	poll := func(height int, mempool ...*parser.Transaction) {
+		g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{Blocks: height}
+		g_mempoolGeneration++
+		var mempoolList []string
+		for _, tx := range mempool {
+			mempoolList = append(mempoolList, txidstr(tx))
+		}
+		untrackMempoolTxs(mempoolList, g_mempoolGeneration)
+	}
+	expect := func(eventType walletrpc.MempoolEvent_Type, tx, conflicting *parser.Transaction, height int) {
+		var event *walletrpc.MempoolEvent
+		select {
+		case event = <-sub.C:
+		default:
+			t.Fatal("no event, expecting ", eventType)
+		}
+		if event.Type != eventType || !bytes.Equal(event.Txid, tx.GetEncodableHash()) ||
+			event.Height != uint64(height) {
+			t.Fatal("unexpected event ", event)
+		}
+		if conflicting != nil && !bytes.Equal(event.ConflictingTxid, conflicting.GetEncodableHash()) {
+			t.Fatal("unexpected conflicting txid ", event.ConflictingTxid)
+		}
+	}
+	expectNone := func() {
+		select {
+		case event := <-sub.C:
+			t.Fatal("unexpected event ", event)
+		default:
+		}
+	}
+	g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{Blocks: 380641}
+	for _, tx := range []*parser.Transaction{txA, txC, txD, txE} {
+		trackMempoolTx(txid(txidstr(tx)), tx.Bytes())
+		expect(walletrpc.MempoolEvent_ADDED, tx, nil, 380641)
+	}
+
+	// Once the cache has the blocks, A is found to have been mined and C to
+	// conflict with B, which was; D, still valid at its expiry height, was
+	// evicted.
+	poll(380643, txE)
+	expectNone()
+	for i := 2; i < 4; i++ {
+		if err := testcache.Add(380640+i, parseTestBlock(t, i).ToCompact()); err != nil {
+			t.Fatal("cache.Add failed:", err)
+		}
+	}
+	poll(380643, txE)
+	expect(walletrpc.MempoolEvent_MINED, txA, nil, 380643)
+	expect(walletrpc.MempoolEvent_CONFLICTED, txC, txB, 380643)
+	expect(walletrpc.MempoolEvent_REMOVED, txD, nil, 380643)
+	expectNone()
+
+	// E expires at the next block.
+	testcache.Add(380644, &walletrpc.CompactBlock{Height: 380644})
+	poll(380644)
+	expect(walletrpc.MempoolEvent_EXPIRED, txE, nil, 380644)
+	expectNone()
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"encoding/json"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/parser/cc"
+)
+
+// reserveTransferDepth is how many of the latest blocks are searched for
+// reserve transfers (they're usually exported within a few blocks).
+const reserveTransferDepth = 10
+
+// PendingReserveTransfer is a reserve transfer output in the mempool or a
+// recent block.
+type PendingReserveTransfer struct {
+	Txid     []byte // little-endian, as in the proto messages
+	Vout     uint32
+	Height   int // zero if in the mempool
+	Transfer *cc.ReserveTransfer
+}
+
+// reserveTransfers returns the reserve transfers in the given transaction;
+// outputs that can't be decoded are skipped.
+func reserveTransfers(tx *parser.Transaction, height int) []PendingReserveTransfer {
+	var transfers []PendingReserveTransfer
+	for vout, script := range tx.CCOutputs() {
+		if script == nil {
+			continue
+		}
+		transfer, err := script.ReserveTransfer()
+		if err != nil {
+			Log.Warning("can't decode reserve transfer ", displayHash(tx.GetEncodableHash()), " ", vout, ": ", err)
+			continue
+		}
+		if transfer == nil {
+			continue
+		}
+		transfers = append(transfers, PendingReserveTransfer{
+			Txid:     tx.GetEncodableHash(),
+			Vout:     uint32(vout),
+			Height:   height,
+			Transfer: transfer,
+		})
+	}
+	return transfers
+}
+
+// blockReserveTransfers returns the reserve transfers in the block at the
+// given height, which are stored in the cache as of that block.
+func blockReserveTransfers(cache *BlockCache, height int) ([]PendingReserveTransfer, error) {
+	result, _ := cache.GetBlockResult(height, "reservetransfers")
+	if result != nil {
+		var transfers []PendingReserveTransfer
+		if err := json.Unmarshal(result, &transfers); err == nil {
+			return transfers, nil
+		}
+	}
+	block, err := getFullBlockFromRPC(height)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	transfers := []PendingReserveTransfer{}
+	for _, tx := range block.Transactions() {
+		transfers = append(transfers, reserveTransfers(tx, height)...)
+	}
+	if result, err := json.Marshal(transfers); err == nil {
+		cache.PutBlockResult(height, block.GetEncodableHash(), "reservetransfers", result)
+	}
+	return transfers, nil
+}
+
+// GetPendingReserveTransfers returns the reserve transfers in the latest few
+// blocks (oldest first) followed by those in the mempool.
+func GetPendingReserveTransfers(cache *BlockCache) ([]PendingReserveTransfer, error) {
+	if err := NodeUnavailable(); err != nil {
+		return nil, err
+	}
+	var transfers []PendingReserveTransfer
+	latest := cache.GetLatestHeight()
+	start := latest - reserveTransferDepth + 1
+	if start < cache.GetFirstHeight() {
+		start = cache.GetFirstHeight()
+	}
+	for height := start; latest >= 0 && height <= latest; height++ {
+		blockTransfers, err := blockReserveTransfers(cache, height)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, blockTransfers...)
+	}
+	mempool, err := MempoolSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	for _, rtx := range mempool {
+		tx := parser.NewTransaction()
+		if rest, err := tx.ParseFromSlice(rtx.Data); err != nil || len(rest) != 0 {
+			continue
+		}
+		transfers = append(transfers, reserveTransfers(tx, 0)...)
+	}
+	return transfers, nil
+}
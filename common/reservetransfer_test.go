@@ -0,0 +1,96 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/asherda/lightwalletd/parser/cc"
+)
+
+// A v4 transaction with a single output, a reserve transfer of 1 VRSC to the
+// R-address with key ID 5a4a...0b0e (in PUSHDATA1s, within the CC script).
+const reserveTransferTxHex = "0400008085202f89" + "00" + "01" +
+	"0000000000000000" + "b5" + // value, script length
+	"27" + "0403000101" + "21" + "029f3c1a5e6b2d8c4f7a0e1b3d5c7f9a2b4d6e8f0a1c3e5b7d9f2a4c6e8b0d1f3a" + "cc" +
+	"4c89" + "0403080101" + "21" + "029f3c1a5e6b2d8c4f7a0e1b3d5c7f9a2b4d6e8f0a1c3e5b7d9f2a4c6e8b0d1f3a" +
+	"4c60" + "01" + "01" + "a6ef9ea235635e328124ff3429db9f9e91b64e2d" + "00e1f50500000000" +
+	"03" + "a6ef9ea235635e328124ff3429db9f9e91b64e2d" + "809b20" +
+	"02" + "14" + "5a4a4bd4a3fed4dd98a1c1adb7ff5e7ad4a05b0e" +
+	"c1d4a3a2c1e0f7b8d9a6e5f4c3b2a19080706050" + "75" +
+	"00000000" + "00000000" + "0000000000000000" + "00" + "00" + "00"
+
+// Number of getblock requests that reached zcashd
+var getblockRequests int
+
+func reserveTransferStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	switch method {
+	case "getblock":
+		getblockRequests++
+		var height string
+		json.Unmarshal(params[0], &height)
+		switch height {
+		case "380640":
+			return blocks[0], nil
+		case "380641":
+			return blocks[1], nil
+		}
+		testT.Fatal("unexpected getblock height ", height)
+	case "getblockchaininfo":
+		return []byte(`{"blocks": 380641, "bestblockhash": "0102"}`), nil
+	case "getrawmempool":
+		return []byte(`["mempooltxid"]`), nil
+	case "getrawtransaction":
+		return []byte(`"` + reserveTransferTxHex + `"`), nil
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestGetPendingReserveTransfers(t *testing.T) {
+	testT = t
+	waitMempoolIdle(t)
+	RawRequest = reserveTransferStub
+	Time.Now = time.Now
+	g_lastTime = time.Time{}
+	g_lastBlockChainInfo = &ZcashdRpcReplyGetblockchaininfo{}
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+	defer func() {
+		g_txidSeen = map[txid]struct{}{}
+		g_epoch = newMempoolEpoch()
+		getblockRequests = 0
+		os.RemoveAll(unitTestPath)
+	}()
+	for i := 0; i < 2; i++ {
+		if err := testcache.Add(380640+i, parseTestBlock(t, i).ToCompact()); err != nil {
+			t.Fatal("cache.Add failed:", err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		transfers, err := GetPendingReserveTransfers(testcache)
+		if err != nil {
+			t.Fatal("GetPendingReserveTransfers failed:", err)
+		}
+		// The test blocks have no reserve transfers, the mempool has one.
+		if len(transfers) != 1 || transfers[0].Height != 0 || transfers[0].Vout != 0 {
+			t.Fatal("unexpected reserve transfers ", transfers)
+		}
+		rt := transfers[0].Transfer
+		if rt.Destination.DestType() != cc.TransferDestPubKeyHash ||
+			hex.EncodeToString(rt.Destination.Bytes) != "5a4a4bd4a3fed4dd98a1c1adb7ff5e7ad4a05b0e" ||
+			len(rt.Values) != 1 || rt.Values[0].Amount != 100000000 || rt.Fees != 20000 {
+			t.Fatalf("unexpected reserve transfer %+v", rt)
+		}
+	}
+	// The blocks' (lack of) transfers are stored, so they're only fetched once.
+	if getblockRequests != 2 {
+		t.Fatal("unexpected number of getblock requests ", getblockRequests)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"sort"
+	"time"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ValidateBlocks makes the block ingestor check each block zcashd returns
+// before adding it to the cache, rather than trusting zcashd completely.
+var ValidateBlocks bool
+
+const (
+	// How far ahead of our clock a block's timestamp may be (as in zcashd).
+	maxFutureBlockTime = 2 * time.Hour
+
+	// The number of preceding blocks whose median time a block's timestamp
+	// must exceed.
+	medianTimeSpan = 11
+)
+
+// rejectedBlocks counts the blocks that failed validation, by reason.
+var rejectedBlocks = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "lightwalletd_rejected_blocks_total",
+	Help: "Blocks from zcashd that failed validation and weren't cached.",
+}, []string{"reason"})
+
+func init() {
+	prometheus.MustRegister(rejectedBlocks)
+}
+
+// medianTimePast returns the median timestamp of the (up to 11) cached
+// blocks before the given height, or zero if there are none.
+func medianTimePast(c *BlockCache, height int) uint32 {
+	var times []uint32
+	for h := height - 1; h >= c.GetFirstHeight() && len(times) < medianTimeSpan; h-- {
+		block := c.Get(h)
+		if block == nil {
+			break
+		}
+		times = append(times, block.Time)
+	}
+	if len(times) == 0 {
+		return 0
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return times[len(times)/2]
+}
+
+// checkBlock returns an error if the block zcashd returned for the given
+// height isn't consistent: its merkle root doesn't match its transactions,
+// it meets neither the proof-of-work target nor the (checkable)
+// proof-of-stake rules, its coinbase is for a different height, or its
+// timestamp is too far in the future or not after the median of the blocks
+// before it. (A block that doesn't extend the cache is a reorg, handled
+// by the ingestor.) The reason is the rejectedBlocks label.
+func checkBlock(c *BlockCache, height int, block *parser.Block) (reason string, err error) {
+	if err := block.CheckMerkleRoot(); err != nil {
+		return "merkle_root", err
+	}
+	if powErr := block.CheckProofOfWork(); powErr != nil {
+		if err := block.CheckProofOfStake(); err != nil {
+			return "proof", errors.Wrap(err, powErr.Error())
+		}
+	}
+	if block.GetHeight() != height {
+		return "chain", errors.Errorf("coinbase height %d, expected %d", block.GetHeight(), height)
+	}
+	blockTime := time.Unix(int64(block.GetTime()), 0)
+	if blockTime.After(Time.Now().Add(maxFutureBlockTime)) {
+		return "time", errors.Errorf("block time %v is too far in the future", blockTime)
+	}
+	if mtp := medianTimePast(c, height); block.GetTime() <= mtp {
+		return "time", errors.Errorf("block time %d is not after the median time past %d",
+			block.GetTime(), mtp)
+	}
+	return "", nil
+}
+
+// validateBlock checks the block (if ValidateBlocks is set), logging and
+// counting a rejection.
+func validateBlock(c *BlockCache, height int, block *parser.Block) error {
+	if !ValidateBlocks {
+		return nil
+	}
+	reason, err := checkBlock(c, height, block)
+	if err != nil {
+		rejectedBlocks.WithLabelValues(reason).Inc()
+		Log.Warning("rejecting block ", height, " ", displayHash(block.GetEncodableHash()), ": ", err)
+	}
+	return err
+}
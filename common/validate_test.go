@@ -0,0 +1,182 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/parser/verushash"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func counterValue(c prometheus.Counter) float64 {
+	var m dto.Metric
+	c.Write(&m)
+	return m.GetCounter().GetValue()
+}
+
+func TestValidateBlock(t *testing.T) {
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380640, false)
+	Time.Now = time.Now
+	defer func() {
+		ValidateBlocks = false
+		os.RemoveAll(unitTestPath)
+	}()
+	for i := 0; i < 2; i++ {
+		if err := testcache.Add(380640+i, parseTestBlock(t, i).ToCompact()); err != nil {
+			t.Fatal("cache.Add failed:", err)
+		}
+	}
+	if mtp := medianTimePast(testcache, 380642); mtp != testcache.Get(380641).Time {
+		t.Fatal("unexpected median time past ", mtp)
+	}
+	if mtp := medianTimePast(testcache, 380640); mtp != 0 {
+		t.Fatal("unexpected median time past ", mtp)
+	}
+
+	// The third test block ends with a transaction that could be a stake, but
+	// isn't marked as proof of stake, and its hash doesn't meet its target.
+	if reason, _ := checkBlock(testcache, 380642, parseTestBlock(t, 2)); reason != "proof" {
+		t.Fatal("unexpected checkBlock reason ", reason)
+	}
+
+	// Marked, it passes the checks (as proof of stake), at its height.
+	block := proofOfStakeTestBlock(t, 2)
+	if reason, err := checkBlock(testcache, 380642, block); err != nil {
+		t.Fatal("checkBlock failed:", reason, err)
+	}
+	if reason, _ := checkBlock(testcache, 380643, block); reason != "chain" {
+		t.Fatal("unexpected checkBlock reason ", reason)
+	}
+	Time.Now = func() time.Time { return time.Unix(int64(block.GetTime()), 0).Add(-3 * time.Hour) }
+	if reason, _ := checkBlock(testcache, 380642, block); reason != "time" {
+		t.Fatal("unexpected checkBlock reason ", reason)
+	}
+	Time.Now = time.Now
+	if reason, _ := checkBlock(testcache, 380642, parseTestBlock(t, 1)); reason != "proof" {
+		t.Fatal("unexpected checkBlock reason ", reason)
+	}
+	blockData, _ := block.MarshalBinary()
+	blockData[36]++ // the merkle root
+	tampered := parser.NewBlock()
+	tampered.ParseFromSlice(blockData)
+	if reason, _ := checkBlock(testcache, 380642, tampered); reason != "merkle_root" {
+		t.Fatal("unexpected checkBlock reason ", reason)
+	}
+
+	// Rejections are only made (and counted) when validating.
+	rejected := counterValue(rejectedBlocks.WithLabelValues("merkle_root"))
+	if err := validateBlock(testcache, 380642, tampered); err != nil {
+		t.Fatal("validateBlock should have succeeded, not validating")
+	}
+	ValidateBlocks = true
+	if err := validateBlock(testcache, 380642, tampered); err == nil {
+		t.Fatal("validateBlock should have failed")
+	}
+	if err := validateBlock(testcache, 380642, block); err != nil {
+		t.Fatal("validateBlock failed:", err)
+	}
+	if counterValue(rejectedBlocks.WithLabelValues("merkle_root")) != rejected+1 {
+		t.Fatal("rejected block wasn't counted")
+	}
+}
+
+// proofOfStakeTestBlock returns the test block with its nonce marking it as
+// proof of stake (see CPOSNonce::SetPOSTarget), with its nBits as the stake
+// target.
+func proofOfStakeTestBlock(t *testing.T, i int) *parser.Block {
+	var blockHex string
+	json.Unmarshal(blocks[i], &blockHex)
+	blockData, _ := hex.DecodeString(blockHex)
+	nonce := blockData[108:140]
+	copy(nonce[:4], blockData[104:108])
+	lowNonce := make([]byte, 32)
+	copy(lowNonce, nonce[:16])
+	copy(nonce[16:], verushash.VerusHash(lowNonce)[:16])
+	block := parser.NewBlock()
+	if _, err := block.ParseFromSlice(blockData); err != nil {
+		t.Fatal("could not parse test block", err)
+	}
+	return block
+}
+
+// zcashd first returns the third test block, unmarked (so it's rejected),
+// then replaces it with the block marked as proof of stake.
+func rejectedBlockStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	switch method {
+	case "getbestblockhash":
+		r, _ := json.Marshal("010101") // doesn't match, so not synced
+		return r, nil
+	case "getblock":
+		var height string
+		json.Unmarshal(params[0], &height)
+		if height != "380642" {
+			testT.Fatal("unexpected height requested ", height)
+		}
+		if step <= 2 {
+			return blocks[2], nil
+		}
+		blockData, _ := proofOfStakeTestBlock(testT, 2).MarshalBinary()
+		return json.Marshal(hex.EncodeToString(blockData))
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestBlockIngestorRejected(t *testing.T) {
+	testT = t
+	RawRequest = rejectedBlockStub
+	Time.Sleep = sleepStub
+	Time.Now = time.Now
+	ValidateBlocks = true
+	step = 0
+	sleepCount = 0
+	sleepDuration = 0
+	os.RemoveAll(unitTestPath)
+	testcache = openTestCache(380642, false)
+	defer func() {
+		ValidateBlocks = false
+		step = 0
+		sleepCount = 0
+		sleepDuration = 0
+		os.RemoveAll(unitTestPath)
+	}()
+
+	// The rejected block is counted and waited out, rather than retried as
+	// if zcashd were unavailable.
+	rejected := counterValue(rejectedBlocks.WithLabelValues("proof"))
+	BlockIngestor(testcache, 1)
+	if counterValue(rejectedBlocks.WithLabelValues("proof")) != rejected+1 {
+		t.Fatal("rejected block wasn't counted")
+	}
+	if NodeError() != nil {
+		t.Fatal("zcashd shouldn't be unhealthy ", NodeError())
+	}
+	if sleepCount != 1 || sleepDuration != 2*time.Second {
+		t.Fatal("unexpected sleepCount ", sleepCount, " sleepDuration ", sleepDuration)
+	}
+	if testcache.GetNextHeight() != 380642 {
+		t.Fatal("rejected block was cached")
+	}
+
+	// The replacement is accepted.
+	BlockIngestor(testcache, 1)
+	if testcache.GetNextHeight() != 380643 {
+		t.Fatal("replacement block wasn't cached ", testcache.GetNextHeight())
+	}
+	if testcache.GetHeader(testcache.Get(380642).Hash) == nil {
+		t.Fatal("replacement block's header wasn't cached")
+	}
+	if sleepCount != 1 {
+		t.Fatal("unexpected sleepCount ", sleepCount)
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+// Optional subscriber to zcashd's ZMQ notifications (-zmqpubhashblock and
+// -zmqpubrawtx). It speaks just enough of ZMTP 3.0 (the ZeroMQ wire protocol,
+// https://rfc.zeromq.org/spec/23/) to be a SUB socket with the NULL security
+// mechanism, which is all zcashd offers, so there's no need for libzmq.
+//
+// The notifications only make things faster: while they arrive, the block
+// ingestor and the mempool poll zcashd much less often, but if the socket
+// goes quiet, they go back to polling every 2 seconds.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	zmqTopicHashBlock = "hashblock"
+	zmqTopicRawTx     = "rawtx"
+
+	// Largest frame we'll accept; a transaction is at most 2 MB.
+	zmqMaxFrameSize = 4 * 1024 * 1024
+
+	// ZMTP frame flags.
+	zmqFlagMore    = 0x01
+	zmqFlagLong    = 0x02
+	zmqFlagCommand = 0x04
+)
+
+var (
+	// If a subscription has received nothing for this long, it's considered
+	// quiet (zcashd may have gone away), and we go back to polling.
+	zmqQuietTimeout = 3 * time.Minute
+
+	// While notifications are arriving, we still poll this often, in case
+	// one was missed.
+	zmqPollInterval = 30 * time.Second
+
+	// A new hashblock notification is signalled here (for BlockIngestor).
+	zmqBlockChan = make(chan struct{}, 1)
+
+	zmqState struct {
+		mutex   sync.Mutex
+		lastMsg map[string]time.Time // most recent message time, by topic
+	}
+)
+
+// StartZMQ subscribes to zcashd's hashblock and rawtx ZMQ notifications at
+// the given endpoints (such as "tcp://127.0.0.1:28332", as given to zcashd's
+// -zmqpubhashblock and -zmqpubrawtx); either may be empty. It returns
+// immediately; the subscriptions run (and reconnect) until the process exits.
+func StartZMQ(hashblockAddr, rawtxAddr string) {
+	topics := make(map[string][]string)
+	if hashblockAddr != "" {
+		topics[hashblockAddr] = append(topics[hashblockAddr], zmqTopicHashBlock)
+	}
+	if rawtxAddr != "" {
+		topics[rawtxAddr] = append(topics[rawtxAddr], zmqTopicRawTx)
+	}
+	for addr, t := range topics {
+		go zmqSubscribe(addr, t)
+	}
+}
+
+// zmqSubscribe keeps a subscription to the given topics at the given
+// endpoint, reconnecting (with backoff) as needed.
+func zmqSubscribe(addr string, topics []string) {
+	backoff := time.Second
+	for {
+		start := time.Now()
+		err := zmqReceive(addr, topics, zmqHandleMessage)
+		Log.WithFields(logrus.Fields{
+			"address": addr,
+			"error":   err,
+		}).Warn("zmq subscription lost, polling zcashd instead")
+		if time.Since(start) > zmqQuietTimeout {
+			backoff = time.Second
+		}
+		time.Sleep(backoff)
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+// zmqReceive connects to the given endpoint, subscribes to the topics, and
+// passes each message to handle until an error occurs.
+func zmqReceive(addr string, topics []string, handle func(topic string, body []byte)) error {
+	network, address, err := zmqParseEndpoint(addr)
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout(network, address, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	r := bufio.NewReader(conn)
+	if err = zmqHandshake(conn, r, "SUB"); err != nil {
+		return errors.Wrap(err, "zmq handshake")
+	}
+	for _, topic := range topics {
+		// ZMTP 3.0 subscription: a message of 1 followed by the prefix
+		if err = zmqWriteFrame(conn, 0, append([]byte{1}, topic...)); err != nil {
+			return err
+		}
+	}
+	Log.Info("zmq subscribed to ", strings.Join(topics, ", "), " at ", addr)
+
+	for {
+		// zcashd doesn't send heartbeats; a long silence, not unusual
+		// for rawtx, just means we reconnect to be sure.
+		conn.SetReadDeadline(time.Now().Add(2 * zmqQuietTimeout))
+		msg, err := zmqReadMessage(r)
+		if err != nil {
+			return err
+		}
+		// zcashd sends the topic, the body, and a sequence number.
+		if len(msg) < 2 {
+			continue
+		}
+		handle(string(msg[0]), msg[1])
+	}
+}
+
+func zmqParseEndpoint(addr string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(addr, "tcp://"):
+		return "tcp", strings.TrimPrefix(addr, "tcp://"), nil
+	case strings.HasPrefix(addr, "ipc://"):
+		return "unix", strings.TrimPrefix(addr, "ipc://"), nil
+	}
+	return "", "", errors.New("unsupported zmq endpoint (must be tcp:// or ipc://): " + addr)
+}
+
+// zmqHandshake exchanges greetings and READY commands with the peer, for the
+// NULL mechanism, announcing the given socket type.
+func zmqHandshake(w io.Writer, r *bufio.Reader, socketType string) error {
+	greeting := make([]byte, 64)
+	greeting[0] = 0xff
+	greeting[9] = 0x7f
+	greeting[10] = 3 // version 3.0
+	copy(greeting[12:32], "NULL")
+	if _, err := w.Write(greeting); err != nil {
+		return err
+	}
+	peer := make([]byte, 64)
+	if _, err := io.ReadFull(r, peer); err != nil {
+		return err
+	}
+	if peer[0] != 0xff || peer[9] != 0x7f || peer[10] < 3 {
+		return errors.New("peer is not ZMTP 3")
+	}
+	if string(bytes.TrimRight(peer[12:32], "\x00")) != "NULL" {
+		return errors.New("peer security mechanism is not NULL")
+	}
+
+	// READY, with metadata property Socket-Type
+	ready := []byte("\x05READY\x0bSocket-Type")
+	ready = binary.BigEndian.AppendUint32(ready, uint32(len(socketType)))
+	ready = append(ready, socketType...)
+	if err := zmqWriteFrame(w, zmqFlagCommand, ready); err != nil {
+		return err
+	}
+	flags, body, err := zmqReadFrame(r)
+	if err != nil {
+		return err
+	}
+	if flags&zmqFlagCommand == 0 || len(body) < 1 || len(body) < 1+int(body[0]) {
+		return errors.New("expected READY command")
+	}
+	if name := string(body[1 : 1+body[0]]); name != "READY" {
+		return errors.New("expected READY command, got " + name)
+	}
+	return nil
+}
+
+func zmqWriteFrame(w io.Writer, flags byte, body []byte) error {
+	var header []byte
+	if len(body) > 255 {
+		header = binary.BigEndian.AppendUint64([]byte{flags | zmqFlagLong}, uint64(len(body)))
+	} else {
+		header = []byte{flags, byte(len(body))}
+	}
+	_, err := w.Write(append(header, body...))
+	return err
+}
+
+func zmqReadFrame(r *bufio.Reader) (byte, []byte, error) {
+	flags, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	var size uint64
+	if flags&zmqFlagLong != 0 {
+		var b [8]byte
+		if _, err = io.ReadFull(r, b[:]); err != nil {
+			return 0, nil, err
+		}
+		size = binary.BigEndian.Uint64(b[:])
+	} else {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		size = uint64(b)
+	}
+	if size > zmqMaxFrameSize {
+		return 0, nil, errors.New("zmq frame too large")
+	}
+	body := make([]byte, size)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return flags, body, nil
+}
+
+// zmqReadMessage returns the frames of the next message, skipping commands.
+func zmqReadMessage(r *bufio.Reader) ([][]byte, error) {
+	var msg [][]byte
+	for {
+		flags, body, err := zmqReadFrame(r)
+		if err != nil {
+			return nil, err
+		}
+		if flags&zmqFlagCommand != 0 {
+			continue
+		}
+		msg = append(msg, body)
+		if flags&zmqFlagMore == 0 {
+			return msg, nil
+		}
+	}
+}
+
+func zmqHandleMessage(topic string, body []byte) {
+	zmqState.mutex.Lock()
+	if zmqState.lastMsg == nil {
+		zmqState.lastMsg = make(map[string]time.Time)
+	}
+	zmqState.lastMsg[topic] = time.Now()
+	zmqState.mutex.Unlock()
+
+	switch topic {
+	case zmqTopicHashBlock:
+		select {
+		case zmqBlockChan <- struct{}{}:
+		default:
+		}
+		wakeMempoolTracker()
+	case zmqTopicRawTx:
+		addMempoolTx(body)
+	}
+}
+
+// zmqActive reports whether notifications for the given topic have been
+// arriving recently.
+func zmqActive(topic string) bool {
+	zmqState.mutex.Lock()
+	defer zmqState.mutex.Unlock()
+	last, ok := zmqState.lastMsg[topic]
+	return ok && time.Since(last) < zmqQuietTimeout
+}
+
+// waitForBlock waits until zcashd may have a new block: until a hashblock
+// notification arrives, if they are, else for the usual polling interval. It
+// returns false if, instead, the block ingestor is asked to stop.
+func waitForBlock() bool {
+	if !zmqActive(zmqTopicHashBlock) {
+		Time.Sleep(2 * time.Second)
+		return true
+	}
+	select {
+	case <-zmqBlockChan:
+	case <-Time.After(zmqPollInterval):
+	case <-stopIngestorChan:
+		return false
+	}
+	return true
+}
+
+// addMempoolTx keeps a transaction from a rawtx notification for the mempool
+// tracker, and has it poll right away: if zcashd's mempool has it, the
+// tracker adds it to the current epoch without a getrawtransaction. zcashd
+// notifies about the transactions in each new block too, so a transaction is
+// only added once getrawmempool lists it.
+func addMempoolTx(txBytes []byte) {
+	tx := parser.NewTransaction()
+	rest, err := tx.ParseFromSlice(txBytes)
+	if err != nil || len(rest) != 0 {
+		Log.Warning("zmq rawtx: can't parse transaction: ", err)
+		return
+	}
+	if tx.IsCoinbase() {
+		// It's certainly from a block.
+		return
+	}
+	txidstr := txid(hex.EncodeToString(tx.GetDisplayHash()))
+
+	if atomic.LoadInt32(&g_clients) == 0 {
+		// The next GetMempool will fetch it with getrawmempool.
+		return
+	}
+	g_lock.Lock()
+	g_notifiedTxs[txidstr] = notifiedTx{data: txBytes, generation: g_mempoolGeneration}
+	g_lock.Unlock()
+	wakeMempoolTracker()
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// A (version 1) transaction spending the given outpoint, with a 300-byte
+// scriptSig, so it takes a long ZMTP frame.
+func zmqTestTx(prevHash byte, prevIndex string) []byte {
+	tx, _ := hex.DecodeString("01000000" + "01" +
+		strings.Repeat(hex.EncodeToString([]byte{prevHash}), 32) + prevIndex +
+		"fd2c01" + strings.Repeat("51", 300) + "ffffffff" +
+		"01" + "0100000000000000" + "00" + "00000000")
+	return tx
+}
+
+// zmqTestPublisher is a stand-in for zcashd's ZMQ PUB socket. The protocol
+// bytes are spelled out here, rather than using the code under test.
+func zmqTestPublisher(t *testing.T, conn net.Conn, messages [][][]byte) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	greeting := make([]byte, 64)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		t.Error("publisher: reading greeting:", err)
+		return
+	}
+	if greeting[0] != 0xff || greeting[9] != 0x7f || greeting[10] != 3 ||
+		!bytes.Equal(greeting[12:17], []byte("NULL\x00")) {
+		t.Errorf("publisher: unexpected greeting %x", greeting)
+	}
+	// ZMTP 3.1 greeting, NULL mechanism, then READY (Socket-Type PUB)
+	conn.Write(append([]byte{0xff, 0, 0, 0, 0, 0, 0, 0, 1, 0x7f, 3, 1}, append([]byte("NULL"), make([]byte, 48)...)...))
+	conn.Write([]byte("\x04\x19\x05READY\x0bSocket-Type\x00\x00\x00\x03PUB"))
+
+	expect := func(want string) {
+		got := make([]byte, len(want))
+		if _, err := io.ReadFull(conn, got); err != nil {
+			t.Error("publisher: reading:", err)
+			return
+		}
+		if string(got) != want {
+			t.Errorf("publisher: got %q, expecting %q", got, want)
+		}
+	}
+	expect("\x04\x19\x05READY\x0bSocket-Type\x00\x00\x00\x03SUB")
+	expect("\x00\x0a\x01hashblock")
+	expect("\x00\x06\x01rawtx")
+
+	// A PING command (which the subscriber should ignore), then the messages.
+	conn.Write([]byte("\x04\x07\x04PING\x00\x00"))
+	for seq, msg := range messages {
+		msg = append(msg, binary.LittleEndian.AppendUint32(nil, uint32(seq)))
+		for i, frame := range msg {
+			var flags byte
+			if i < len(msg)-1 {
+				flags = zmqFlagMore
+			}
+			if len(frame) > 255 {
+				conn.Write(binary.BigEndian.AppendUint64([]byte{flags | zmqFlagLong}, uint64(len(frame))))
+			} else {
+				conn.Write([]byte{flags, byte(len(frame))})
+			}
+			conn.Write(frame)
+		}
+	}
+}
+
+func TestZMQReceive(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	hash := bytes.Repeat([]byte{0xab}, 32)
+	tx := zmqTestTx(0x11, "00000000")
+	messages := [][][]byte{
+		{[]byte("hashblock"), hash},
+		{[]byte("rawtx"), tx},
+	}
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			t.Error("publisher: accept:", err)
+			return
+		}
+		zmqTestPublisher(t, conn, messages)
+	}()
+
+	type received struct {
+		topic string
+		body  []byte
+	}
+	var got []received
+	err = zmqReceive("tcp://"+listener.Addr().String(), []string{"hashblock", "rawtx"},
+		func(topic string, body []byte) {
+			got = append(got, received{topic, body})
+		})
+	// The publisher hangs up after its messages.
+	if err != io.EOF {
+		t.Fatal("zmqReceive unexpected error:", err)
+	}
+	if len(got) != 2 {
+		t.Fatal("zmqReceive unexpected number of messages:", len(got))
+	}
+	if got[0].topic != "hashblock" || !bytes.Equal(got[0].body, hash) {
+		t.Fatal("zmqReceive unexpected hashblock message")
+	}
+	if got[1].topic != "rawtx" || !bytes.Equal(got[1].body, tx) {
+		t.Fatal("zmqReceive unexpected rawtx message")
+	}
+
+	if err = zmqReceive("udp://127.0.0.1:1", nil, nil); err == nil {
+		t.Fatal("zmqReceive should have failed, bad endpoint")
+	}
+}
+
+func TestZMQHandleMessage(t *testing.T) {
+	// Rather than hang, fail if an earlier failed test left this locked.
+	if !g_lock.TryLock() {
+		t.Fatal("g_lock is held")
+	}
+	g_lock.Unlock()
+	defer func() {
+		zmqState.lastMsg = nil
+		g_notifiedTxs = map[txid]notifiedTx{}
+	}()
+	if zmqActive(zmqTopicHashBlock) {
+		t.Fatal("unexpected zmq state before any notifications")
+	}
+
+	hash := bytes.Repeat([]byte{0xcd}, 32)
+	zmqHandleMessage(zmqTopicHashBlock, hash)
+	if !zmqActive(zmqTopicHashBlock) || zmqActive(zmqTopicRawTx) {
+		t.Fatal("unexpected zmqActive")
+	}
+	// This shouldn't sleep, the notification has already arrived.
+	done := make(chan struct{})
+	go func() {
+		if !waitForBlock() {
+			t.Error("waitForBlock stopped")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("waitForBlock didn't notice the hashblock notification")
+	}
+	// Nor does it hold up stopping the ingestor.
+	Time.After = func(time.Duration) <-chan time.Time { return nil }
+	defer func() { Time.After = time.After }()
+	waited := make(chan bool)
+	go func() { waited <- waitForBlock() }()
+	if !StopBlockIngestor(5 * time.Second) {
+		t.Fatal("waitForBlock didn't notice the ingestor stopping")
+	}
+	if <-waited {
+		t.Fatal("waitForBlock should have reported stopping")
+	}
+
+	// With no GetMempool clients, transactions aren't kept.
+	tx := zmqTestTx(0x11, "00000000")
+	zmqHandleMessage(zmqTopicRawTx, tx)
+	if len(g_notifiedTxs) != 0 {
+		t.Fatal("unexpected mempool transaction with no clients")
+	}
+	atomic.StoreInt32(&g_clients, 1)
+	defer atomic.StoreInt32(&g_clients, 0)
+	zmqHandleMessage(zmqTopicRawTx, tx)
+	zmqHandleMessage(zmqTopicRawTx, tx)
+	// a coinbase (from a new block) isn't kept
+	zmqHandleMessage(zmqTopicRawTx, zmqTestTx(0, "ffffffff"))
+	// nor is garbage
+	zmqHandleMessage(zmqTopicRawTx, []byte{1, 2, 3})
+	if len(g_notifiedTxs) != 1 {
+		t.Fatal("unexpected notified transactions ", len(g_notifiedTxs))
+	}
+	for _, notified := range g_notifiedTxs {
+		if !bytes.Equal(notified.data, tx) {
+			t.Fatal("unexpected notified transaction")
+		}
+	}
+	// The transaction isn't in the mempool until getrawmempool lists it,
+	// but the tracker is woken to call it.
+	if len(g_epoch.txs) != 0 {
+		t.Fatal("unexpected mempool transactions")
+	}
+	select {
+	case <-mempoolPollChan:
+	default:
+		t.Fatal("mempool tracker not woken")
+	}
+	if !zmqActive(zmqTopicRawTx) {
+		t.Fatal("unexpected zmqActive")
+	}
+}
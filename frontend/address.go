@@ -0,0 +1,177 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package frontend
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/asherda/lightwalletd/common"
+	"github.com/asherda/lightwalletd/parser/cc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Version bytes of Verus transparent addresses, which are base58check
+// encodings of the version byte followed by a 20-byte hash.
+const (
+	pubKeyHashVersion = 60  // R-address
+	scriptHashVersion = 85  // b-address
+	identityVersion   = 102 // i-address
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Decode returns the bytes encoded by the given base58 string.
+func base58Decode(s string) ([]byte, bool) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range s {
+		digit := strings.IndexRune(base58Alphabet, c)
+		if digit < 0 {
+			return nil, false
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+	// Each leading '1' encodes a leading zero byte.
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), true
+}
+
+// base58Encode is the inverse of base58Decode.
+func base58Encode(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	digit := new(big.Int)
+	var encoded []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, digit)
+		encoded = append(encoded, base58Alphabet[digit.Int64()])
+	}
+	for i := 0; i < len(data) && data[i] == 0; i++ {
+		encoded = append(encoded, '1')
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// encodeAddress returns the base58check address with the given version byte
+// and 20-byte hash (such as a currency ID, as an i-address).
+func encodeAddress(version byte, hash []byte) string {
+	data := append([]byte{version}, hash...)
+	check := sha256.Sum256(data)
+	check = sha256.Sum256(check[:])
+	return base58Encode(append(data, check[:4]...))
+}
+
+// decodeAddress returns the version byte and hash of a valid transparent
+// address (see checkTaddress).
+func decodeAddress(taddr string) (byte, []byte, error) {
+	if err := checkTaddress(taddr); err != nil {
+		return 0, nil, err
+	}
+	data, _ := base58Decode(taddr)
+	return data[0], data[1:21], nil
+}
+
+// transferDestinationVersions are the address version bytes of the reserve
+// transfer destination types that are transparent addresses.
+var transferDestinationVersions = map[uint8]byte{
+	cc.TransferDestPubKeyHash: pubKeyHashVersion,
+	cc.TransferDestScriptHash: scriptHashVersion,
+	cc.TransferDestID:         identityVersion,
+}
+
+// transferDestinationAddress returns the address of the given reserve
+// transfer destination, or its bytes in hex if it isn't an R-, b- or
+// i-address.
+func transferDestinationAddress(d *cc.TransferDestination) string {
+	if version, ok := transferDestinationVersions[d.DestType()]; ok && len(d.Bytes) == 20 {
+		return encodeAddress(version, d.Bytes)
+	}
+	return hex.EncodeToString(d.Bytes)
+}
+
+// transferDestinationMatches returns whether the given reserve transfer
+// destination is the address with the given version byte and hash.
+func transferDestinationMatches(d *cc.TransferDestination, version byte, hash []byte) bool {
+	v, ok := transferDestinationVersions[d.DestType()]
+	return ok && v == version && bytes.Equal(d.Bytes, hash)
+}
+
+func invalidAddress(taddr, reason string) error {
+	return status.Errorf(codes.InvalidArgument, "Invalid address %q: %s", taddr, reason)
+}
+
+// checkTaddress returns nil if the given string is a valid transparent
+// address: an R-address (public key hash), b-address (script hash) or
+// i-address (identity), else an InvalidArgument error.
+func checkTaddress(taddr string) error {
+	// Leave room for leading zero bytes, but don't decode anything huge.
+	if len(taddr) > 64 {
+		return invalidAddress(taddr[:64]+"...", "too long")
+	}
+	data, ok := base58Decode(taddr)
+	if !ok {
+		return invalidAddress(taddr, "not base58")
+	}
+	if len(data) != 1+20+4 {
+		return invalidAddress(taddr, "wrong length")
+	}
+	hash := sha256.Sum256(data[:21])
+	hash = sha256.Sum256(hash[:])
+	if !bytes.Equal(hash[:4], data[21:]) {
+		return invalidAddress(taddr, "bad checksum")
+	}
+	switch data[0] {
+	case pubKeyHashVersion, scriptHashVersion, identityVersion:
+		return nil
+	}
+	return invalidAddress(taddr, "not an R-, b- or i-address")
+}
+
+// resolveAddress checks the given transparent address; if it's instead a
+// VerusID friendly name (such as "alice@" or "alice.VRSC@"), it returns the
+// identity's i-address, which is what the node's address index uses.
+func resolveAddress(cache *common.BlockCache, addr string) (string, error) {
+	name := strings.TrimSuffix(addr, "@")
+	if name == addr {
+		return addr, checkTaddress(addr)
+	}
+	if name == "" || strings.Contains(name, "@") || len(name) > 255 {
+		return "", status.Errorf(codes.InvalidArgument, "Invalid identity name %q", addr)
+	}
+	reply, err := common.GetIdentity(cache, addr, 0)
+	if err != nil {
+		// For some reason, the error responses are not JSON
+		errCode := strings.Split(err.Error(), ":")[0]
+		if errCode == "-5" || errCode == "-8" {
+			return "", status.Errorf(codes.InvalidArgument, "Unknown identity %q", addr)
+		}
+		return "", err
+	}
+	return reply.Identity.IdentityAddress, checkTaddress(reply.Identity.IdentityAddress)
+}
+
+// resolveAddresses is resolveAddress for a list of addresses.
+func resolveAddresses(cache *common.BlockCache, addrs []string) ([]string, error) {
+	resolved := make([]string, len(addrs))
+	for i, addr := range addrs {
+		var err error
+		if resolved[i], err = resolveAddress(cache, addr); err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
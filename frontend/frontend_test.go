@@ -11,13 +11,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/asherda/lightwalletd/common"
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/parser/cc"
 	"github.com/asherda/lightwalletd/walletrpc"
 	"github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -36,8 +45,13 @@ const (
 
 func testsetup() (walletrpc.CompactTxStreamerServer, *common.BlockCache) {
 	os.RemoveAll(unitTestPath)
-	cache := common.NewBlockCache(unitTestPath, unitTestChain, 380640, true)
-	lwd, err := NewLwdStreamer(cache, "main", false /* enablePing */)
+	db, err := leveldb.OpenFile(unitTestPath, nil)
+	if err != nil {
+		os.Stderr.WriteString(fmt.Sprint("leveldb.OpenFile failed:", err))
+		os.Exit(1)
+	}
+	cache := common.NewBlockCache(db, unitTestChain, 380640, true)
+	lwd, err := NewLwdStreamer(cache, "main", false /* enablePing */, false /* fullHeaders */)
 	if err != nil {
 		os.Stderr.WriteString(fmt.Sprint("NewLwdStreamer failed:", err))
 		os.Exit(1)
@@ -115,7 +129,7 @@ func TestGetTransaction(t *testing.T) {
 	if err == nil {
 		testT.Fatal("GetTransaction unexpectedly succeeded")
 	}
-	if err.Error() != "Please call GetTransaction with txid" {
+	if err.Error() != "Please call GetTransaction with txid or block and index" {
 		testT.Fatal("GetTransaction unexpected error message")
 	}
 	if rawtx != nil {
@@ -127,7 +141,7 @@ func TestGetTransaction(t *testing.T) {
 	if err == nil {
 		testT.Fatal("GetTransaction unexpectedly succeeded")
 	}
-	if err.Error() != "Can't GetTransaction with a blockhash+num. Please call GetTransaction with txid" {
+	if err.Error() != "Block hash has invalid length" {
 		testT.Fatal("GetTransaction unexpected error message")
 	}
 	if rawtx != nil {
@@ -135,6 +149,146 @@ func TestGetTransaction(t *testing.T) {
 	}
 }
 
+// A zcashd without -txindex; it can't find mined transactions.
+func getblockNoTxindexStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	switch method {
+	case "getblock":
+		var id string
+		err := json.Unmarshal(params[0], &id)
+		if err != nil {
+			testT.Fatal("could not unmarshal block id")
+		}
+		if id != "380640" && id != hex.EncodeToString(parser.Reverse(testBlock(0).GetEncodableHash())) {
+			return nil, errors.New("-8: Block height out of range")
+		}
+		return blocks[0], nil
+	case "getrawtransaction":
+		return nil, errors.New("-5: No such mempool transaction. Use -txindex to enable blockchain transaction queries")
+	}
+	testT.Fatal("unexpected call to getblockNoTxindexStub")
+	return nil, nil
+}
+
+// testBlock returns the given test block, parsed.
+func testBlock(i int) *parser.Block {
+	var blockHex string
+	json.Unmarshal(blocks[i], &blockHex)
+	blockData, _ := hex.DecodeString(blockHex)
+	block := parser.NewBlock()
+	if _, err := block.ParseFromSlice(blockData); err != nil {
+		testT.Fatal("could not parse test block", err)
+	}
+	return block
+}
+
+func TestGetTransactionByBlock(t *testing.T) {
+	testT = t
+	common.RawRequest = getblockNoTxindexStub
+	lwd, cache := testsetup()
+	block := testBlock(0)
+	txs := block.Transactions()
+
+	// By block height and index
+	for i, tx := range txs {
+		rawtx, err := lwd.GetTransaction(context.Background(),
+			&walletrpc.TxFilter{Block: &walletrpc.BlockID{Height: 380640}, Index: uint64(i)})
+		if err != nil {
+			t.Fatal("GetTransaction by block and index failed:", err)
+		}
+		if !bytes.Equal(rawtx.Data, tx.Bytes()) || rawtx.Height != 380640 {
+			t.Fatal("GetTransaction by block and index returned unexpected transaction", i)
+		}
+	}
+	// By block hash and index (the hash takes precedence over the height)
+	last := len(txs) - 1
+	rawtx, err := lwd.GetTransaction(context.Background(),
+		&walletrpc.TxFilter{Block: &walletrpc.BlockID{Height: 1, Hash: block.GetEncodableHash()}, Index: uint64(last)})
+	if err != nil {
+		t.Fatal("GetTransaction by block hash and index failed:", err)
+	}
+	if !bytes.Equal(rawtx.Data, txs[last].Bytes()) || rawtx.Height != 380640 {
+		t.Fatal("GetTransaction by block hash and index returned unexpected transaction")
+	}
+	_, err = lwd.GetTransaction(context.Background(),
+		&walletrpc.TxFilter{Block: &walletrpc.BlockID{Height: 380640}, Index: uint64(len(txs))})
+	if err == nil || err.Error() != "transaction index is out of range" {
+		t.Fatal("GetTransaction with index out of range unexpected result:", err)
+	}
+	_, err = lwd.GetTransaction(context.Background(),
+		&walletrpc.TxFilter{Block: &walletrpc.BlockID{Height: 380641}})
+	if err == nil {
+		t.Fatal("GetTransaction with unknown block should have failed")
+	}
+
+	// By txid, before the block is in the cache; zcashd can't find it.
+	txid := txs[last].GetEncodableHash()
+	_, err = lwd.GetTransaction(context.Background(), &walletrpc.TxFilter{Hash: txid})
+	if err == nil || !strings.HasPrefix(err.Error(), "-5:") {
+		t.Fatal("GetTransaction by txid unexpected result:", err)
+	}
+
+	// Once the block is cached, its txids are indexed; fall back to getblock.
+	txids := make([][]byte, len(txs))
+	for i, tx := range txs {
+		txids[i] = tx.GetEncodableHash()
+	}
+	if err = cache.AddWithTxids(380640, block.ToCompact(), txids, nil); err != nil {
+		t.Fatal("cache.AddWithTxids failed:", err)
+	}
+	rawtx, err = lwd.GetTransaction(context.Background(), &walletrpc.TxFilter{Hash: txid})
+	if err != nil {
+		t.Fatal("GetTransaction by txid failed:", err)
+	}
+	if !bytes.Equal(rawtx.Data, txs[last].Bytes()) || rawtx.Height != 380640 {
+		t.Fatal("GetTransaction by txid returned unexpected transaction")
+	}
+	step = 0
+}
+
+func TestGetTransactionProof(t *testing.T) {
+	testT = t
+	common.RawRequest = getblockNoTxindexStub
+	lwd, cache := testsetup()
+	block := testBlock(0)
+	txid := block.Transactions()[0].GetEncodableHash()
+
+	if _, err := lwd.GetTransactionProof(context.Background(), &walletrpc.TxFilter{}); err == nil {
+		t.Fatal("GetTransactionProof unexpectedly succeeded")
+	}
+	proof, err := lwd.GetTransactionProof(context.Background(),
+		&walletrpc.TxFilter{Block: &walletrpc.BlockID{Height: 380640}})
+	if err != nil {
+		t.Fatal("GetTransactionProof by block and index failed:", err)
+	}
+	if !bytes.Equal(proof.Txid, txid) || proof.Height != 380640 || proof.Index != 0 {
+		t.Fatal("GetTransactionProof returned an unexpected proof ", proof)
+	}
+	if err := parser.VerifyTransactionProof(proof); err != nil {
+		t.Fatal("VerifyTransactionProof failed:", err)
+	}
+	if _, err := lwd.GetTransactionProof(context.Background(),
+		&walletrpc.TxFilter{Block: &walletrpc.BlockID{Height: 380640}, Index: 1}); err == nil {
+		t.Fatal("GetTransactionProof with index out of range should have failed")
+	}
+
+	// By txid, zcashd can't find it until the block is cached.
+	if _, err := lwd.GetTransactionProof(context.Background(), &walletrpc.TxFilter{Hash: txid}); err == nil {
+		t.Fatal("GetTransactionProof by txid should have failed")
+	}
+	if err := cache.AddWithTxids(380640, block.ToCompact(), [][]byte{txid}, nil); err != nil {
+		t.Fatal("cache.AddWithTxids failed:", err)
+	}
+	proof, err = lwd.GetTransactionProof(context.Background(), &walletrpc.TxFilter{Hash: txid})
+	if err != nil {
+		t.Fatal("GetTransactionProof by txid failed:", err)
+	}
+	if err := parser.VerifyTransactionProof(proof); err != nil || !bytes.Equal(proof.Txid, txid) {
+		t.Fatal("unexpected proof by txid ", proof, err)
+	}
+	step = 0
+}
+
 func getblockStub(method string, params []json.RawMessage) (json.RawMessage, error) {
 	step++
 	var height string
@@ -199,17 +353,28 @@ func TestGetLatestBlock(t *testing.T) {
 
 // A valid address starts with "t", followed by 34 alpha characters;
 // these should all be detected as invalid.
+// Valid transparent addresses, all of the same hash
+const (
+	testRAddress = "RHWbtyLkdLTqyKo38qb3sRSwGvBT83XdMP"
+	testIAddress = "iBhwFXrrSuveKXegATaSEgsyi7151rpRfN"
+	testBAddress = "bLxgWgnxNr3kRAHCkKv1zZFc1Xd2sn8T4q"
+)
+
 var addressTests = []string{
-	"",                                      // too short
-	"a",                                     // too short
-	"t123456789012345678901234567890123",    // one byte too short
-	"t12345678901234567890123456789012345",  // one byte too long
-	"t123456789012345678901234567890123*",   // invalid "*"
-	"s1234567890123456789012345678901234",   // doesn't start with "t"
-	" t1234567890123456789012345678901234",  // extra stuff before
-	"t1234567890123456789012345678901234 ",  // extra stuff after
-	"\nt1234567890123456789012345678901234", // newline before
-	"t1234567890123456789012345678901234\n", // newline after
+	"",                                     // too short
+	"a",                                    // too short
+	"RHWbtyLkdLTqyKo38qb3sRSwGvBT83XdM",    // one character too short
+	"RHWbtyLkdLTqyKo38qb3sRSwGvBT83XdMPP",  // one character too long
+	"RHWbtyLkdLTqyKo38qb3sRSwGvBT83XdM0",   // invalid "0"
+	"RHWbtyLkdLTqyKo38qb3sRSwGvBT4WjzUX",   // bad checksum
+	"19EQpTTU2WfGuKRqffbvmu7jWeirVto45N",   // bitcoin (version 0)
+	"t1S71pnsbzqSsVxUjc6R3uiDemJuwG1Hg9h",  // zcash (two version bytes)
+	" RHWbtyLkdLTqyKo38qb3sRSwGvBT83XdMP",  // extra stuff before
+	"RHWbtyLkdLTqyKo38qb3sRSwGvBT83XdMP ",  // extra stuff after
+	"\nRHWbtyLkdLTqyKo38qb3sRSwGvBT83XdMP", // newline before
+	"RHWbtyLkdLTqyKo38qb3sRSwGvBT83XdMP\n", // newline after
+	"@",                                    // empty identity name
+	"alice@bob@",                           // not an identity name
 }
 
 func zcashdrpcStub(method string, params []json.RawMessage) (json.RawMessage, error) {
@@ -224,7 +389,7 @@ func zcashdrpcStub(method string, params []json.RawMessage) (json.RawMessage, er
 		if len(filter.Addresses) != 1 {
 			testT.Fatal("wrong number of addresses")
 		}
-		if filter.Addresses[0] != "t1234567890123456789012345678901234" {
+		if filter.Addresses[0] != testRAddress {
 			testT.Fatal("wrong address")
 		}
 		if filter.Start != 20 {
@@ -288,13 +453,13 @@ func TestGetTaddressTxids(t *testing.T) {
 		if err == nil {
 			t.Fatal("GetTaddressTxids should have failed on bad address, case", i)
 		}
-		if err.Error() != "Invalid address" {
+		if status.Code(err) != codes.InvalidArgument {
 			t.Fatal("GetTaddressTxids incorrect error on bad address, case", i)
 		}
 	}
 
 	// valid address
-	addressBlockFilter.Address = "t1234567890123456789012345678901234"
+	addressBlockFilter.Address = testRAddress
 	err := lwd.GetTaddressTxids(addressBlockFilter, &testgettx{})
 	if err != nil {
 		t.Fatal("GetTaddressTxids failed", err)
@@ -363,8 +528,8 @@ func TestGetBlock(t *testing.T) {
 	if err == nil {
 		t.Fatal("GetBlock should have failed")
 	}
-	if err.Error() != "GetBlock by Hash is not yet implemented" {
-		t.Fatal("GetBlock hash unimplemented error message failed")
+	if err.Error() != "Block hash has invalid length" {
+		t.Fatal("GetBlock hash invalid length error message failed")
 	}
 
 	// getblockStub() case 1: return error
@@ -386,6 +551,46 @@ func TestGetBlock(t *testing.T) {
 	step = 0
 }
 
+func TestGetBlockByHash(t *testing.T) {
+	testT = t
+	common.RawRequest = getblockStub
+	lwd, cache := testsetup()
+
+	// getblockStub() case 1: the block arrives by height, add it to the cache
+	block, err := lwd.GetBlock(context.Background(), &walletrpc.BlockID{Height: 380640})
+	if err != nil {
+		t.Fatal("GetBlock failed:", err)
+	}
+	if err = cache.Add(380640, block); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+
+	// The hash lookup is satisfied from the cache (no rpc), and the
+	// hash takes precedence over a (wrong) height.
+	byHash, err := lwd.GetBlock(context.Background(),
+		&walletrpc.BlockID{Height: 1, Hash: block.Hash})
+	if err != nil {
+		t.Fatal("GetBlock by hash failed:", err)
+	}
+	if byHash.Height != 380640 || !bytes.Equal(byHash.Hash, block.Hash) {
+		t.Fatal("GetBlock by hash returned unexpected block")
+	}
+
+	// A range specified by hash
+	blockrange := &walletrpc.BlockRange{
+		Start: &walletrpc.BlockID{Hash: block.Hash},
+		End:   &walletrpc.BlockID{Hash: block.Hash},
+	}
+	err = lwd.GetBlockRange(blockrange, &testgetbrange{})
+	if err != nil {
+		t.Fatal("GetBlockRange by hash failed", err)
+	}
+	if step != 1 {
+		t.Fatal("unexpected getblock rpc")
+	}
+	step = 0
+}
+
 type testgetbrange struct {
 	walletrpc.CompactTxStreamer_GetBlockRangeServer
 }
@@ -421,6 +626,222 @@ func TestGetBlockRange(t *testing.T) {
 	step = 0
 }
 
+// Records the blocks sent
+type testgetbrangeblocks struct {
+	walletrpc.CompactTxStreamer_GetBlockRangeServer
+	blocks []*walletrpc.CompactBlock
+}
+
+func (tg *testgetbrangeblocks) Context() context.Context {
+	return context.Background()
+}
+
+func (tg *testgetbrangeblocks) Send(cb *walletrpc.CompactBlock) error {
+	tg.blocks = append(tg.blocks, cb)
+	return nil
+}
+
+func TestGetBlockRangePools(t *testing.T) {
+	testT = t
+	lwd, cache := testsetup()
+	defer cache.Close()
+	block := testBlock(0)
+	if err := cache.Add(380640, block.ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+	blockRange := func(poolTypes ...walletrpc.PoolType) *walletrpc.CompactBlock {
+		resp := &testgetbrangeblocks{}
+		err := lwd.GetBlockRange(&walletrpc.BlockRange{
+			Start:     &walletrpc.BlockID{Height: 380640},
+			End:       &walletrpc.BlockID{Height: 380640},
+			PoolTypes: poolTypes,
+		}, resp)
+		if err != nil || len(resp.blocks) != 1 {
+			t.Fatal("GetBlockRange failed ", poolTypes, err)
+		}
+		return resp.blocks[0]
+	}
+
+	// By default, only transactions with Sapling data, without transparent data.
+	saplingTxns := 0
+	for _, tx := range block.Transactions() {
+		if tx.HasSaplingElements() {
+			saplingTxns++
+		}
+	}
+	cb := blockRange()
+	if len(cb.Vtx) != saplingTxns {
+		t.Fatal("unexpected number of transactions ", len(cb.Vtx), saplingTxns)
+	}
+	for _, tx := range cb.Vtx {
+		if len(tx.Vin) != 0 || len(tx.Vout) != 0 {
+			t.Fatal("unexpected transparent data")
+		}
+	}
+	if again := blockRange(walletrpc.PoolType_SAPLING); len(again.Vtx) != len(cb.Vtx) {
+		t.Fatal("Sapling should be the default")
+	}
+
+	// The coinbase (at least) has transparent outputs, but no inputs.
+	cb = blockRange(walletrpc.PoolType_TRANSPARENT)
+	if len(cb.Vtx) == 0 || cb.Vtx[0].Index != 0 || len(cb.Vtx[0].Vin) != 0 || len(cb.Vtx[0].Vout) == 0 {
+		t.Fatal("unexpected transparent block ", cb)
+	}
+	for _, tx := range cb.Vtx {
+		if len(tx.Spends) != 0 || len(tx.Outputs) != 0 {
+			t.Fatal("unexpected Sapling data")
+		}
+	}
+	cb = blockRange(walletrpc.PoolType_TRANSPARENT, walletrpc.PoolType_SAPLING)
+	if len(cb.Vtx) != block.GetTxCount() {
+		t.Fatal("unexpected number of transactions ", len(cb.Vtx))
+	}
+
+	// GetBlock is Sapling only.
+	if cb, err := lwd.GetBlock(context.Background(), &walletrpc.BlockID{Height: 380640}); err != nil ||
+		len(cb.Vtx) != saplingTxns {
+		t.Fatal("unexpected GetBlock reply ", cb, err)
+	}
+
+	err := lwd.GetBlockRange(&walletrpc.BlockRange{
+		Start:     &walletrpc.BlockID{Height: 380640},
+		End:       &walletrpc.BlockID{Height: 380640},
+		PoolTypes: []walletrpc.PoolType{walletrpc.PoolType_POOL_TYPE_INVALID},
+	}, &testgetbrangeblocks{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("GetBlockRange should have failed, invalid pool type ", err)
+	}
+}
+
+type testgetheaders struct {
+	walletrpc.CompactTxStreamer_GetBlockHeaderRangeServer
+	headers []*walletrpc.BlockHeader
+}
+
+func (tg *testgetheaders) Context() context.Context {
+	return context.Background()
+}
+
+func (tg *testgetheaders) Send(h *walletrpc.BlockHeader) error {
+	tg.headers = append(tg.headers, h)
+	return nil
+}
+
+func TestGetBlockHeaderRange(t *testing.T) {
+	testT = t
+	lwd, cache := testsetup()
+	defer cache.Close()
+	for i := 0; i < 2; i++ {
+		header, _ := testBlock(i).MarshalHeader()
+		if err := cache.AddWithTxids(380640+i, testBlock(i).ToCompact(), nil, header); err != nil {
+			t.Fatal("cache.AddWithTxids failed:", err)
+		}
+	}
+	// The header is stored apart from the compact block.
+	if cache.Get(380640).Header != nil {
+		t.Fatal("header stored in the compact block")
+	}
+	resp := &testgetheaders{}
+	err := lwd.GetBlockHeaderRange(&walletrpc.BlockRange{
+		Start: &walletrpc.BlockID{Height: 380641},
+		End:   &walletrpc.BlockID{Height: 380640},
+	}, resp)
+	if err != nil || len(resp.headers) != 2 {
+		t.Fatal("GetBlockHeaderRange failed ", err)
+	}
+	for i, h := range resp.headers {
+		block := testBlock(1 - i)
+		hdr := parser.NewBlockHeader()
+		if rest, err := hdr.ParseFromSlice(h.Header); err != nil || len(rest) != 0 {
+			t.Fatal("can't parse header ", err)
+		}
+		if h.Height != uint64(block.GetHeight()) || !bytes.Equal(h.Hash, block.GetEncodableHash()) ||
+			!bytes.Equal(hdr.GetEncodableHash(), h.Hash) || !bytes.Equal(hdr.HashPrevBlock, block.GetPrevHash()) {
+			t.Fatal("unexpected header ", i)
+		}
+	}
+	if err := lwd.GetBlockHeaderRange(&walletrpc.BlockRange{Start: &walletrpc.BlockID{Height: 380640}},
+		&testgetheaders{}); err == nil {
+		t.Fatal("GetBlockHeaderRange should have failed, no end")
+	}
+
+	// Compact blocks include the header only if enabled.
+	if cb, err := lwd.GetBlock(context.Background(), &walletrpc.BlockID{Height: 380640}); err != nil || cb.Header != nil {
+		t.Fatal("unexpected GetBlock reply ", cb, err)
+	}
+	withHeaders := &lwdStreamer{cache: cache, fullHeaders: true}
+	cb, err := withHeaders.GetBlock(context.Background(), &walletrpc.BlockID{Height: 380640})
+	if err != nil || !bytes.Equal(cb.Header, resp.headers[1].Header) {
+		t.Fatal("unexpected GetBlock reply with headers ", cb, err)
+	}
+}
+
+type testsubscribe struct {
+	walletrpc.CompactTxStreamer_SubscribeBlocksServer
+	ctx    context.Context
+	events chan *walletrpc.BlockEvent
+}
+
+func (ts *testsubscribe) Context() context.Context {
+	return ts.ctx
+}
+
+func (ts *testsubscribe) Send(event *walletrpc.BlockEvent) error {
+	ts.events <- event
+	return nil
+}
+
+func TestSubscribeBlocks(t *testing.T) {
+	testT = t
+	lwd, cache := testsetup()
+	defer cache.Close()
+	for i := 0; i < 2; i++ {
+		if err := cache.Add(380640+i, testBlock(i).ToCompact()); err != nil {
+			t.Fatal("cache.Add failed:", err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	resp := &testsubscribe{ctx: ctx, events: make(chan *walletrpc.BlockEvent)}
+	done := make(chan error)
+	go func() {
+		done <- lwd.SubscribeBlocks(&walletrpc.BlockID{Height: 380641}, resp)
+	}()
+
+	// The backlog, then the blocks and reorgs as they happen
+	if event := <-resp.events; event.Block == nil || event.Block.Height != 380641 {
+		t.Fatal("unexpected backlog event ", event)
+	}
+	if err := cache.Add(380642, testBlock(2).ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+	if event := <-resp.events; event.Block == nil || event.Block.Height != 380642 ||
+		event.Block.Header != nil {
+		t.Fatal("unexpected block event ", event)
+	}
+	cache.Reorg(380640)
+	if event := <-resp.events; event.Block != nil || event.ReorgHeight != 380640 {
+		t.Fatal("unexpected reorg event ", event)
+	}
+	// The replacement is sent, although it's below the starting height.
+	if err := cache.Add(380640, testBlock(0).ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+	if event := <-resp.events; event.Block == nil || event.Block.Height != 380640 {
+		t.Fatal("unexpected block event ", event)
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatal("unexpected SubscribeBlocks result ", err)
+	}
+
+	if err := lwd.SubscribeBlocks(&walletrpc.BlockID{Hash: []byte{0}}, resp); err == nil {
+		t.Fatal("SubscribeBlocks should have failed, bad hash")
+	}
+	if err := lwd.SubscribeBlocks(&walletrpc.BlockID{Height: 1}, resp); err == nil {
+		t.Fatal("SubscribeBlocks should have failed, not cached")
+	}
+}
+
 func TestGetBlockRangeNilArgs(t *testing.T) {
 	lwd, _ := testsetup()
 
@@ -495,6 +916,410 @@ func TestSendTransaction(t *testing.T) {
 	step = 0
 }
 
+// zcashd is down (or, once it's back, the cache is synced).
+func nodeDownStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	return nil, errors.New("connection refused")
+}
+
+func nodeUpStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	if method != "getbestblockhash" {
+		testT.Fatal("unexpected method ", method)
+	}
+	return json.Marshal(hex.EncodeToString(parser.Reverse(testBlock(0).GetEncodableHash())))
+}
+
+func TestNodeUnavailable(t *testing.T) {
+	testT = t
+	lwd, cache := testsetup()
+	common.Time.Sleep = func(d time.Duration) {}
+	// Don't wait out the retry backoff.
+	common.Time.After = func(d time.Duration) <-chan time.Time { return time.After(0) }
+	common.Time.Now = time.Now
+	if err := cache.Add(380640, testBlock(0).ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+
+	// The block ingestor notices zcashd is down.
+	common.RawRequest = nodeDownStub
+	common.BlockIngestor(cache, 1)
+
+	// Cached blocks are still served.
+	if _, err := lwd.GetBlock(context.Background(), &walletrpc.BlockID{Height: 380640}); err != nil {
+		t.Fatal("GetBlock of a cached block failed:", err)
+	}
+	if _, err := lwd.GetLatestBlock(context.Background(), &walletrpc.ChainSpec{}); err != nil {
+		t.Fatal("GetLatestBlock failed:", err)
+	}
+	// But anything needing zcashd is unavailable.
+	if _, err := lwd.GetBlock(context.Background(), &walletrpc.BlockID{Height: 380641}); status.Code(err) != codes.Unavailable {
+		t.Fatal("GetBlock unexpected error:", err)
+	}
+	if _, err := lwd.SendTransaction(context.Background(), &walletrpc.RawTransaction{Data: []byte{7}}); status.Code(err) != codes.Unavailable {
+		t.Fatal("SendTransaction unexpected error:", err)
+	}
+	if _, err := lwd.GetTransaction(context.Background(), &walletrpc.TxFilter{Hash: make([]byte, 32)}); status.Code(err) != codes.Unavailable {
+		t.Fatal("GetTransaction unexpected error:", err)
+	}
+	if _, err := lwd.GetTaddressBalance(context.Background(), &walletrpc.AddressList{Addresses: []string{testRAddress}}); status.Code(err) != codes.Unavailable {
+		t.Fatal("GetTaddressBalance unexpected error:", err)
+	}
+
+	// zcashd is back.
+	common.RawRequest = nodeUpStub
+	common.BlockIngestor(cache, 1)
+	if common.NodeUnavailable() != nil {
+		t.Fatal("zcashd should be available")
+	}
+}
+
+func TestHealthServer(t *testing.T) {
+	service := walletrpc.CompactTxStreamer_ServiceDesc.ServiceName
+	h := NewHealthServer(service)
+	check := func(want healthpb.HealthCheckResponse_ServingStatus, wantHTTP int) {
+		t.Helper()
+		for _, name := range []string{"", service} {
+			resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+			if err != nil {
+				t.Fatal("Check failed:", err)
+			}
+			if resp.Status != want {
+				t.Fatalf("service %q unexpected status %v", name, resp.Status)
+			}
+		}
+		w := httptest.NewRecorder()
+		h.Readyz(w, httptest.NewRequest("GET", "/readyz", nil))
+		if w.Code != wantHTTP {
+			t.Fatal("unexpected /readyz status ", w.Code)
+		}
+		// Liveness doesn't depend on readiness.
+		w = httptest.NewRecorder()
+		h.Healthz(w, httptest.NewRequest("GET", "/healthz", nil))
+		if w.Code != http.StatusOK {
+			t.Fatal("unexpected /healthz status ", w.Code)
+		}
+	}
+	check(healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable)
+	h.SetReady(nil)
+	check(healthpb.HealthCheckResponse_SERVING, http.StatusOK)
+	h.SetReady(errors.New("block cache is 11 blocks behind zcashd"))
+	check(healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable)
+
+	if _, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Fatal("unexpected Check error for an unknown service:", err)
+	}
+}
+
+// Replies as from verusd (its JSON field names are lowercase)
+func identityStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	txid := strings.Repeat("01", 31) + "02"
+	switch method {
+	case "getidentity":
+		return []byte(`{"fullyqualifiedname": "alice.VRSC@", "status": "active",
+			"canspendfor": false, "cansignfor": true, "blockheight": 380640,
+			"txid": "` + txid + `", "vout": 1,
+			"identity": {"version": 3, "flags": 0, "minimumsignatures": 1,
+				"primaryaddresses": ["RCdXBieidGuXmK8Tw2gBoXWxi16UgqyKc7"],
+				"name": "alice", "identityaddress": "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq",
+				"parent": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+				"systemid": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+				"contentmap": {"00": "ff"},
+				"revocationauthority": "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq",
+				"recoveryauthority": "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq",
+				"privateaddress": "zs1test", "timelock": 0}}`), nil
+	case "getidentityhistory":
+		return []byte(`{"fullyqualifiedname": "alice.VRSC@", "status": "active",
+			"history": [{"identity": {"name": "alice"}, "blockhash": "` + txid + `",
+				"height": 380640, "output": {"txid": "` + txid + `", "voutnum": 1}}]}`), nil
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestGetIdentity(t *testing.T) {
+	testT = t
+	common.RawRequest = identityStub
+	lwd, cache := testsetup()
+	defer cache.Close()
+
+	info, err := lwd.GetIdentity(context.Background(), &walletrpc.IdentityRequest{Identity: "alice@"})
+	if err != nil {
+		t.Fatal("GetIdentity failed:", err)
+	}
+	id := info.Identity
+	if info.FullyQualifiedName != "alice.VRSC@" || !info.CanSignFor || info.BlockHeight != 380640 || info.Vout != 1 {
+		t.Fatal("unexpected GetIdentity reply ", info)
+	}
+	if id.Version != 3 || id.IdentityAddress != "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq" ||
+		len(id.PrimaryAddresses) != 1 || id.SystemId == "" || id.ContentMap["00"] != "ff" ||
+		id.PrivateAddress != "zs1test" || id.RecoveryAuthority == "" {
+		t.Fatal("unexpected GetIdentity identity ", id)
+	}
+	// Like other txids, little-endian
+	if info.Txid[0] != 2 {
+		t.Fatal("unexpected GetIdentity txid ", hex.EncodeToString(info.Txid))
+	}
+
+	history, err := lwd.GetIdentityHistory(context.Background(), &walletrpc.IdentityHistoryRequest{Identity: "alice@"})
+	if err != nil {
+		t.Fatal("GetIdentityHistory failed:", err)
+	}
+	if len(history.History) != 1 || history.History[0].Identity.Name != "alice" ||
+		history.History[0].BlockHash[0] != 2 || history.History[0].Vout != 1 {
+		t.Fatal("unexpected GetIdentityHistory reply ", history)
+	}
+	if _, err := lwd.GetIdentity(context.Background(), &walletrpc.IdentityRequest{}); err == nil {
+		t.Fatal("GetIdentity should have failed, no identity")
+	}
+}
+
+func resolveStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	switch method {
+	case "getidentity":
+		var name string
+		json.Unmarshal(params[0], &name)
+		if name != "alice@" {
+			return nil, errors.New("-5: Identity not found")
+		}
+		return json.Marshal(common.ZcashdRpcReplyGetidentity{
+			Identity: common.ZcashdRpcIdentity{Name: "alice", IdentityAddress: testIAddress},
+		})
+	case "getaddressbalance":
+		var req common.ZcashdRpcRequestGetaddressbalance
+		json.Unmarshal(params[0], &req)
+		if len(req.Addresses) != 2 || req.Addresses[0] != testIAddress || req.Addresses[1] != testBAddress {
+			testT.Fatal("unexpected getaddressbalance addresses ", req.Addresses)
+		}
+		return []byte(`{"balance": 5}`), nil
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestResolveAddress(t *testing.T) {
+	testT = t
+	common.RawRequest = resolveStub
+	lwd, cache := testsetup()
+	defer cache.Close()
+
+	for _, addr := range []string{testRAddress, testIAddress, testBAddress} {
+		if err := checkTaddress(addr); err != nil {
+			t.Fatal("checkTaddress failed:", err)
+		}
+	}
+	for i, addr := range addressTests {
+		if _, err := resolveAddress(cache, addr); status.Code(err) != codes.InvalidArgument {
+			t.Fatal("resolveAddress unexpected error, case", i, err)
+		}
+	}
+	if addr, err := resolveAddress(cache, "alice@"); err != nil || addr != testIAddress {
+		t.Fatal("resolveAddress unexpected result ", addr, err)
+	}
+	if _, err := resolveAddress(cache, "bob@"); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("resolveAddress unexpected error for an unknown identity:", err)
+	}
+
+	// Friendly names are resolved before asking for the balance.
+	balance, err := lwd.GetTaddressBalance(context.Background(),
+		&walletrpc.AddressList{Addresses: []string{"alice@", testBAddress}})
+	if err != nil || balance.ValueZat != 5 {
+		t.Fatal("GetTaddressBalance unexpected result ", balance, err)
+	}
+	if _, err := lwd.GetAddressUtxos(context.Background(),
+		&walletrpc.GetAddressUtxosArg{Addresses: []string{"bob@"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("GetAddressUtxos unexpected error for an unknown identity:", err)
+	}
+}
+
+// Replies as from verusd for an address holding VRSC and a token
+func currencyStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	switch method {
+	case "getaddressbalance":
+		return []byte(`{"balance": 150000000, "received": 150000000, "currencybalance":
+			{"i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV": 1.50000000,
+			 "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq": 1000.12345678}}`), nil
+	case "getaddressutxos":
+		return []byte(`[{"address": "` + testRAddress + `", "txid": "` + strings.Repeat("01", 32) + `",
+			"outputIndex": 0, "script": "76a9", "satoshis": 0, "height": 380640,
+			"currencyvalues": {"iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq": 0.00000001}}]`), nil
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestCurrencyValues(t *testing.T) {
+	testT = t
+	common.RawRequest = currencyStub
+	lwd, cache := testsetup()
+	defer cache.Close()
+
+	balance, err := lwd.GetTaddressBalance(context.Background(), &walletrpc.AddressList{Addresses: []string{testRAddress}})
+	if err != nil {
+		t.Fatal("GetTaddressBalance failed:", err)
+	}
+	if balance.ValueZat != 150000000 || len(balance.CurrencyValues) != 2 ||
+		balance.CurrencyValues["i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV"] != 150000000 ||
+		balance.CurrencyValues["iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq"] != 100012345678 {
+		t.Fatal("unexpected GetTaddressBalance reply ", balance)
+	}
+	utxos, err := lwd.GetAddressUtxos(context.Background(), &walletrpc.GetAddressUtxosArg{Addresses: []string{testRAddress}})
+	if err != nil {
+		t.Fatal("GetAddressUtxos failed:", err)
+	}
+	if len(utxos.AddressUtxos) != 1 || utxos.AddressUtxos[0].CurrencyValues["iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq"] != 1 {
+		t.Fatal("unexpected GetAddressUtxos reply ", utxos)
+	}
+}
+
+// A basket currency, as from verusd
+const testCurrencyJSON = `{"version": 1, "options": 33, "name": "Bridge", "fullyqualifiedname": "Bridge.vETH",
+	"currencyid": "i3f7tSctFkiPpiedY8QR5Tep9p4qDVebDx", "parent": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+	"systemid": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV", "notarizationprotocol": 1, "proofprotocol": 1,
+	"startblock": 2, "endblock": 0,
+	"currencies": ["i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV", "iCtawpxUiCc2sEupt7Z4u8SDAncGZpgSKm"],
+	"weights": [0.50000000, 0.50000000], "conversions": [1.00000000, 0.00050000],
+	"initialsupply": 1000.00000000, "idregistrationfees": 100.00000000, "idreferrallevels": 3,
+	"idimportfees": 0.02000000, "notaries": [], "minnotariesconfirm": 0`
+
+func currencyDefinitionStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	state := `{"currencyid": "i3f7tSctFkiPpiedY8QR5Tep9p4qDVebDx", "flags": 49, "supply": 1000.5,
+		"reservecurrencies": [{"currencyid": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+			"weight": 0.5, "reserves": 500.25, "priceinreserve": 1.00000000}]}`
+	switch method {
+	case "getcurrency":
+		return []byte(testCurrencyJSON + `, "bestheight": 380640, "bestcurrencystate": ` + state + `}`), nil
+	case "listcurrencies":
+		return []byte(`[{"currencydefinition": ` + testCurrencyJSON + `}, "bestheight": 380640}]`), nil
+	case "getcurrencystate":
+		return []byte(`[{"height": 380640, "blocktime": 1600000000, "currencystate": ` + state + `}]`), nil
+	}
+	testT.Fatal("unexpected method ", method)
+	return nil, nil
+}
+
+func TestGetCurrency(t *testing.T) {
+	testT = t
+	common.RawRequest = currencyDefinitionStub
+	lwd, cache := testsetup()
+	defer cache.Close()
+	if err := cache.Add(380640, testBlock(0).ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+
+	def, err := lwd.GetCurrency(context.Background(), &walletrpc.CurrencyRequest{Currency: "Bridge.vETH"})
+	if err != nil {
+		t.Fatal("GetCurrency failed:", err)
+	}
+	if def.FullyQualifiedName != "Bridge.vETH" || def.SystemId == "" || def.Options != 33 ||
+		len(def.Currencies) != 2 || def.Weights[1] != 50000000 || def.Conversions[1] != 50000 ||
+		def.InitialSupply != 100000000000 || def.IdImportFees != 2000000 || def.IdReferralLevels != 3 {
+		t.Fatal("unexpected GetCurrency reply ", def)
+	}
+	cs := def.BestCurrencyState
+	if cs == nil || cs.Height != 380640 || cs.Supply != 100050000000 || len(cs.ReserveCurrencies) != 1 ||
+		cs.ReserveCurrencies[0].Reserves != 50025000000 || cs.ReserveCurrencies[0].Weight != 50000000 {
+		t.Fatal("unexpected GetCurrency state ", cs)
+	}
+
+	list, err := lwd.ListCurrencies(context.Background(), &walletrpc.ListCurrenciesRequest{})
+	if err != nil || len(list.Currencies) != 1 || list.Currencies[0].Name != "Bridge" {
+		t.Fatal("unexpected ListCurrencies reply ", list, err)
+	}
+
+	state, err := lwd.GetCurrencyState(context.Background(), &walletrpc.CurrencyStateRequest{Currency: "Bridge.vETH"})
+	if err != nil || state.Height != 380640 || state.Flags != 49 || state.ReserveCurrencies[0].PriceInReserve != 100000000 {
+		t.Fatal("unexpected GetCurrencyState reply ", state, err)
+	}
+
+	// What darkside stages is what it returns.
+	staged := currencyFromProto(def)
+	if again, err := currencyToProto(&staged); err != nil || again.InitialSupply != def.InitialSupply ||
+		again.Weights[1] != def.Weights[1] || again.BestCurrencyState.Supply != cs.Supply {
+		t.Fatal("currencyFromProto didn't round-trip ", again, err)
+	}
+}
+
+func estimateStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	if method != "estimateconversion" || len(params) != 1 ||
+		string(params[0]) != `{"currency":"VRSC","convertto":"vETH","via":"Bridge.vETH","amount":1.50000000}` {
+		testT.Fatal("unexpected request ", method, params)
+	}
+	return []byte(`{"estimatedcurrencyout": 0.00123456, "netinputamount": 1.4985,
+		"estimatedcurrencystate": {"currencyid": "i3f7tSctFkiPpiedY8QR5Tep9p4qDVebDx", "supply": 1000}}`), nil
+}
+
+func TestEstimateConversion(t *testing.T) {
+	testT = t
+	common.RawRequest = estimateStub
+	lwd, cache := testsetup()
+	defer cache.Close()
+	if err := cache.Add(380640, testBlock(0).ToCompact()); err != nil {
+		t.Fatal("cache.Add failed:", err)
+	}
+	estimate, err := lwd.EstimateConversion(context.Background(), &walletrpc.ConversionRequest{
+		Currency: "VRSC", ConvertTo: "vETH", Via: "Bridge.vETH", Amount: 150000000,
+	})
+	if err != nil {
+		t.Fatal("EstimateConversion failed:", err)
+	}
+	if estimate.EstimatedCurrencyOut != 123456 || estimate.NetInputAmount != 149850000 ||
+		estimate.EstimatedCurrencyState.Supply != 100000000000 {
+		t.Fatal("unexpected EstimateConversion reply ", estimate)
+	}
+	_, err = lwd.EstimateConversion(context.Background(), &walletrpc.ConversionRequest{Currency: "VRSC", ConvertTo: "vETH"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("EstimateConversion should have failed, no amount ", err)
+	}
+}
+
+func TestReserveTransferToProto(t *testing.T) {
+	// Encoding an address is the inverse of decoding it.
+	for _, addr := range []string{testRAddress, testIAddress, testBAddress, "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV"} {
+		version, hash, err := decodeAddress(addr)
+		if err != nil || encodeAddress(version, hash) != addr {
+			t.Fatal("encodeAddress didn't round-trip ", addr, err)
+		}
+	}
+	_, vrsc, _ := decodeAddress("i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV")
+	version, keyID, _ := decodeAddress(testRAddress)
+
+	transfer := &common.PendingReserveTransfer{
+		Txid: []byte{1, 2, 3},
+		Vout: 1,
+		Transfer: &cc.ReserveTransfer{
+			Values:         []cc.CurrencyValue{{CurrencyID: vrsc, Amount: 1}, {CurrencyID: vrsc, Amount: 2}},
+			Flags:          cc.TransferValid,
+			FeeCurrencyID:  vrsc,
+			Fees:           20000,
+			Destination:    cc.TransferDestination{Type: cc.TransferDestPubKeyHash, Bytes: keyID},
+			DestCurrencyID: vrsc,
+		},
+	}
+	d := &transfer.Transfer.Destination
+	if !transferDestinationMatches(d, version, keyID) {
+		t.Fatal("transfer destination should match its R-address")
+	}
+	// The same hash as an i-address is a different destination.
+	if transferDestinationMatches(d, identityVersion, keyID) {
+		t.Fatal("transfer destination shouldn't match an i-address")
+	}
+
+	rt := reserveTransferToProto(transfer)
+	if rt.Vout != 1 || rt.Height != 0 || rt.Destination != testRAddress || rt.DestCurrencyId != "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV" ||
+		rt.SecondReserveId != "" || rt.Fees != 20000 || len(rt.Values) != 1 || rt.Values["i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV"] != 3 {
+		t.Fatal("unexpected reserve transfer ", rt)
+	}
+
+	// Destinations that aren't addresses are in hex.
+	d.Type = cc.TransferDestPubKey | cc.TransferDestFlagGateway
+	d.Bytes = []byte{2, 0xab}
+	if rt := reserveTransferToProto(transfer); rt.Destination != "02ab" || rt.DestinationType != 0x81 {
+		t.Fatal("unexpected reserve transfer destination ", rt.Destination, rt.DestinationType)
+	}
+	if transferDestinationMatches(d, version, keyID) {
+		t.Fatal("public key destination shouldn't match")
+	}
+}
+
 var sampleconf = `
 testnet = 1
 rpcport = 18232
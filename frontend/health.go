@@ -0,0 +1,73 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package frontend
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthServer is the standard gRPC health checking service
+// (grpc.health.v1.Health), along with matching /healthz and /readyz HTTP
+// handlers. The server as a whole ("") and each of the given services are
+// SERVING while lightwalletd is ready to serve wallets, else NOT_SERVING.
+type HealthServer struct {
+	*health.Server
+	services []string
+
+	mutex    sync.RWMutex
+	notReady error // why we're not ready, or nil if we are
+}
+
+// NewHealthServer returns a HealthServer for the given services (full gRPC
+// service names), initially not ready.
+func NewHealthServer(services ...string) *HealthServer {
+	h := &HealthServer{
+		Server:   health.NewServer(),
+		services: services,
+	}
+	h.SetReady(errors.New("starting"))
+	return h
+}
+
+// SetReady sets the status of the server and all of its services: SERVING if
+// notReady is nil, else NOT_SERVING, with notReady being the reason.
+func (h *HealthServer) SetReady(notReady error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.notReady = notReady
+	status := healthpb.HealthCheckResponse_SERVING
+	if notReady != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.Server.SetServingStatus("", status)
+	for _, service := range h.services {
+		h.Server.SetServingStatus(service, status)
+	}
+}
+
+// Healthz is the liveness check: it succeeds as long as lightwalletd is
+// running. (A zcashd outage doesn't count; restarting wouldn't help, and
+// lightwalletd exits by itself if the outage lasts too long.)
+func (h *HealthServer) Healthz(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("ok\n"))
+}
+
+// Readyz is the readiness check: it succeeds while the services are SERVING,
+// else it fails (503) with the reason.
+func (h *HealthServer) Readyz(w http.ResponseWriter, r *http.Request) {
+	h.mutex.RLock()
+	notReady := h.notReady
+	h.mutex.RUnlock()
+	if notReady != nil {
+		http.Error(w, "not ready: "+notReady.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Write([]byte("ok\n"))
+}
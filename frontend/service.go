@@ -20,18 +20,21 @@ import (
 	"github.com/asherda/lightwalletd/common"
 	"github.com/asherda/lightwalletd/parser"
 	"github.com/asherda/lightwalletd/walletrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type lwdStreamer struct {
-	cache      *common.BlockCache
-	chainName  string
-	pingEnable bool
+	cache       *common.BlockCache
+	chainName   string
+	pingEnable  bool
+	fullHeaders bool // fill in CompactBlock.header
 	walletrpc.UnimplementedCompactTxStreamerServer
 }
 
 // NewLwdStreamer constructs a gRPC context.
-func NewLwdStreamer(cache *common.BlockCache, chainName string, enablePing bool) (walletrpc.CompactTxStreamerServer, error) {
-	return &lwdStreamer{cache: cache, chainName: chainName, pingEnable: enablePing}, nil
+func NewLwdStreamer(cache *common.BlockCache, chainName string, enablePing, fullHeaders bool) (walletrpc.CompactTxStreamerServer, error) {
+	return &lwdStreamer{cache: cache, chainName: chainName, pingEnable: enablePing, fullHeaders: fullHeaders}, nil
 }
 
 // DarksideStreamer holds the gRPC state for darksidewalletd.
@@ -45,15 +48,6 @@ func NewDarksideStreamer(cache *common.BlockCache) (walletrpc.DarksideStreamerSe
 	return &DarksideStreamer{cache: cache}, nil
 }
 
-// Test to make sure Address is a single t address
-func checkTaddress(taddr string) error {
-	match, err := regexp.Match("\\AR[a-zA-Z0-9]{33}\\z", []byte(taddr))
-	if err != nil || !match {
-		return errors.New("Invalid address")
-	}
-	return nil
-}
-
 // GetLatestBlock returns the height of the best chain, according to zcashd.
 func (s *lwdStreamer) GetLatestBlock(ctx context.Context, placeholder *walletrpc.ChainSpec) (*walletrpc.BlockID, error) {
 	latestBlock := s.cache.GetLatestHeight()
@@ -69,22 +63,26 @@ func (s *lwdStreamer) GetLatestBlock(ctx context.Context, placeholder *walletrpc
 // GetTaddressTxids is a streaming RPC that returns transaction IDs that have
 // the given transparent address (taddr) as either an input or output.
 func (s *lwdStreamer) GetTaddressTxids(addressBlockFilter *walletrpc.TransparentAddressBlockFilter, resp walletrpc.CompactTxStreamer_GetTaddressTxidsServer) error {
-	if err := checkTaddress(addressBlockFilter.Address); err != nil {
+	address, err := resolveAddress(s.cache, addressBlockFilter.Address)
+	if err != nil {
 		return err
 	}
 
 	if addressBlockFilter.Range == nil {
-		return errors.New("Must specify block range")
+		return status.Error(codes.InvalidArgument, "Must specify block range")
 	}
 	if addressBlockFilter.Range.Start == nil {
-		return errors.New("Must specify a start block height")
+		return status.Error(codes.InvalidArgument, "Must specify a start block height")
 	}
 	if addressBlockFilter.Range.End == nil {
-		return errors.New("Must specify an end block height")
+		return status.Error(codes.InvalidArgument, "Must specify an end block height")
+	}
+	if err := common.NodeUnavailable(); err != nil {
+		return err
 	}
 	params := make([]json.RawMessage, 1)
 	request := &common.ZcashdRpcRequestGetaddresstxids{
-		Addresses: []string{addressBlockFilter.Address},
+		Addresses: []string{address},
 		Start:     addressBlockFilter.Range.Start.Height,
 		End:       addressBlockFilter.Range.End.Height,
 	}
@@ -124,8 +122,8 @@ func (s *lwdStreamer) GetTaddressTxids(addressBlockFilter *walletrpc.Transparent
 	return nil
 }
 
-// GetBlock returns the compact block at the requested height. Requesting a
-// block by hash is not yet supported.
+// GetBlock returns the compact block at the requested height or with the
+// requested hash (little-endian, as in CompactBlock.hash).
 func (s *lwdStreamer) GetBlock(ctx context.Context, id *walletrpc.BlockID) (*walletrpc.CompactBlock, error) {
 	if id.Height == 0 && id.Hash == nil {
 		return nil, errors.New("request for unspecified identifier")
@@ -133,8 +131,14 @@ func (s *lwdStreamer) GetBlock(ctx context.Context, id *walletrpc.BlockID) (*wal
 
 	// Precedence: a hash is more specific than a height. If we have it, use it first.
 	if id.Hash != nil {
-		// TODO: Get block by hash
-		return nil, errors.New("GetBlock by Hash is not yet implemented")
+		if len(id.Hash) != 32 {
+			return nil, errors.New("Block hash has invalid length")
+		}
+		cBlock, err := common.GetBlockByHash(s.cache, id.Hash)
+		if err != nil {
+			return nil, err
+		}
+		return s.filterBlock(cBlock, false, true), nil
 	}
 	cBlock, err := common.GetBlock(s.cache, int(id.Height))
 
@@ -142,28 +146,118 @@ func (s *lwdStreamer) GetBlock(ctx context.Context, id *walletrpc.BlockID) (*wal
 		return nil, err
 	}
 
-	return cBlock, err
+	return s.filterBlock(cBlock, false, true), nil
+}
+
+// filterBlock returns the parts of the (cached) compact block selected by
+// the pools, and the header if it's enabled.
+func (s *lwdStreamer) filterBlock(block *walletrpc.CompactBlock, transparent, sapling bool) *walletrpc.CompactBlock {
+	filtered := parser.FilterCompact(block, transparent, sapling)
+	if !s.fullHeaders {
+		filtered.Header = nil
+	} else if filtered.Header == nil {
+		filtered.Header = s.cache.GetHeader(block.Hash)
+	}
+	return filtered
+}
+
+// blockPools returns whether the given pool types (of a GetBlockRange
+// request) select transparent and Sapling data; if there are none, it's
+// only Sapling.
+func blockPools(poolTypes []walletrpc.PoolType) (transparent, sapling bool, err error) {
+	if len(poolTypes) == 0 {
+		return false, true, nil
+	}
+	for _, poolType := range poolTypes {
+		switch poolType {
+		case walletrpc.PoolType_TRANSPARENT:
+			transparent = true
+		case walletrpc.PoolType_SAPLING:
+			sapling = true
+		default:
+			return false, false, status.Errorf(codes.InvalidArgument, "Invalid pool type %v", poolType)
+		}
+	}
+	return transparent, sapling, nil
 }
 
 // GetBlockRange is a streaming RPC that returns blocks, in compact form,
 // (as also returned by GetBlock) from the block height 'start' to height
-// 'end' inclusively.
+// 'end' inclusively. Either end of the range may be given by hash instead.
 func (s *lwdStreamer) GetBlockRange(span *walletrpc.BlockRange, resp walletrpc.CompactTxStreamer_GetBlockRangeServer) error {
+	transparent, sapling, err := blockPools(span.PoolTypes)
+	if err != nil {
+		return err
+	}
+	start, end, err := s.blockRangeHeights(span)
+	if err != nil {
+		return err
+	}
 	blockChan := make(chan *walletrpc.CompactBlock)
 	errChan := make(chan error)
+	go common.GetBlockRange(s.cache, blockChan, errChan, start, end)
+
+	for {
+		select {
+		case err := <-errChan:
+			// this will also catch context.DeadlineExceeded from the timeout
+			return err
+		case cBlock := <-blockChan:
+			err := resp.Send(s.filterBlock(cBlock, transparent, sapling))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// blockRangeHeights returns the heights of the ends of the block range.
+func (s *lwdStreamer) blockRangeHeights(span *walletrpc.BlockRange) (int, int, error) {
 	if span.Start == nil || span.End == nil {
-		return errors.New("Must specify start and end heights")
+		return 0, 0, errors.New("Must specify start and end heights")
+	}
+	for _, id := range []*walletrpc.BlockID{span.Start, span.End} {
+		if id.Hash != nil && len(id.Hash) != 32 {
+			return 0, 0, errors.New("Block hash has invalid length")
+		}
+	}
+	start, err := common.GetBlockIDHeight(s.cache, span.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := common.GetBlockIDHeight(s.cache, span.End)
+	if err != nil {
+		return 0, 0, err
 	}
+	return start, end, nil
+}
 
-	go common.GetBlockRange(s.cache, blockChan, errChan, int(span.Start.Height), int(span.End.Height))
+// GetBlockHeaderRange is a streaming RPC that returns the full headers of
+// the blocks in the given range (as for GetBlockRange), so that clients can
+// follow the chain without downloading compact transactions.
+func (s *lwdStreamer) GetBlockHeaderRange(span *walletrpc.BlockRange, resp walletrpc.CompactTxStreamer_GetBlockHeaderRangeServer) error {
+	start, end, err := s.blockRangeHeights(span)
+	if err != nil {
+		return err
+	}
+	blockChan := make(chan *walletrpc.CompactBlock)
+	errChan := make(chan error)
+	go common.GetBlockRange(s.cache, blockChan, errChan, start, end)
 
 	for {
 		select {
 		case err := <-errChan:
-			// this will also catch context.DeadlineExceeded from the timeout
 			return err
 		case cBlock := <-blockChan:
-			err := resp.Send(cBlock)
+			header := cBlock.Header
+			if header == nil {
+				header = s.cache.GetHeader(cBlock.Hash)
+			}
+			err := resp.Send(&walletrpc.BlockHeader{
+				Height: cBlock.Height,
+				Hash:   cBlock.Hash,
+				Header: header,
+			})
 			if err != nil {
 				return err
 			}
@@ -171,6 +265,69 @@ func (s *lwdStreamer) GetBlockRange(span *walletrpc.BlockRange, resp walletrpc.C
 	}
 }
 
+// SubscribeBlocks streams the cached blocks from the given one on, then
+// each block as the ingestor adds it, and each reorg (so the client can
+// discard its blocks from that height on) until the client cancels. A
+// client that falls too far behind is disconnected.
+func (s *lwdStreamer) SubscribeBlocks(id *walletrpc.BlockID, resp walletrpc.CompactTxStreamer_SubscribeBlocksServer) error {
+	if id.Hash != nil && len(id.Hash) != 32 {
+		return errors.New("Block hash has invalid length")
+	}
+	sub, next := s.cache.Subscribe()
+	defer sub.Close()
+
+	start := next
+	if id.Height != 0 || id.Hash != nil {
+		height, err := common.GetBlockIDHeight(s.cache, id)
+		if err != nil {
+			return err
+		}
+		if height < s.cache.GetFirstHeight() {
+			return status.Errorf(codes.OutOfRange, "Block %d is not cached", height)
+		}
+		start = height
+	}
+	// The backlog; any change to these blocks follows as an event.
+	for height := start; height < next; height++ {
+		cBlock := s.cache.Get(height)
+		if cBlock == nil {
+			// removed by a reorg, which is the next event
+			break
+		}
+		event := &walletrpc.BlockEvent{Block: s.filterBlock(cBlock, false, true)}
+		if err := resp.Send(event); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-resp.Context().Done():
+			return resp.Context().Err()
+		case event, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "Block subscriber fell too far behind")
+			}
+			if event.Block == nil {
+				if event.ReorgHeight < start {
+					// the client needs the replacements
+					start = event.ReorgHeight
+				}
+				err := resp.Send(&walletrpc.BlockEvent{ReorgHeight: uint64(event.ReorgHeight)})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if int(event.Block.Height) < start {
+				continue
+			}
+			if err := resp.Send(&walletrpc.BlockEvent{Block: s.filterBlock(event.Block, false, true)}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // GetTreeState returns the note commitment tree state corresponding to the given block.
 // See section 3.7 of the Zcash protocol specification. It returns several other useful
 // values also (even though they can be obtained using GetBlock).
@@ -179,6 +336,9 @@ func (s *lwdStreamer) GetTreeState(ctx context.Context, id *walletrpc.BlockID) (
 	if id.Height == 0 && id.Hash == nil {
 		return nil, errors.New("request for unspecified identifier")
 	}
+	if err := common.NodeUnavailable(); err != nil {
+		return nil, err
+	}
 	// The Zcash z_gettreestate rpc accepts either a block height or block hash
 	params := make([]json.RawMessage, 1)
 	var hashJSON []byte
@@ -240,12 +400,16 @@ func (s *lwdStreamer) GetLatestTreeState(ctx context.Context, in *walletrpc.Empt
 }
 
 // GetTransaction returns the raw transaction bytes that are returned
-// by the zcashd 'getrawtransaction' RPC.
+// by the zcashd 'getrawtransaction' RPC. The transaction may instead be
+// specified by a block identifier and its index within that block.
 func (s *lwdStreamer) GetTransaction(ctx context.Context, txf *walletrpc.TxFilter) (*walletrpc.RawTransaction, error) {
 	if txf.Hash != nil {
 		if len(txf.Hash) != 32 {
 			return nil, errors.New("Transaction ID has invalid length")
 		}
+		if err := common.NodeUnavailable(); err != nil {
+			return nil, err
+		}
 		leHashStringJSON, err := json.Marshal(hex.EncodeToString(parser.Reverse(txf.Hash)))
 		if err != nil {
 			return nil, err
@@ -258,7 +422,13 @@ func (s *lwdStreamer) GetTransaction(ctx context.Context, txf *walletrpc.TxFilte
 
 		// For some reason, the error responses are not JSON
 		if rpcErr != nil {
-			return nil, rpcErr
+			// Without -txindex, zcashd can find only mempool (and wallet)
+			// transactions; if we know which block it's in, get it from there.
+			tx, err := common.GetCachedTransaction(s.cache, txf.Hash)
+			if err != nil || tx == nil {
+				return nil, rpcErr
+			}
+			return tx, nil
 		}
 		// Many other fields are returned, but we need only these two.
 		var txinfo common.ZcashdRpcReplyGetrawtransaction
@@ -270,16 +440,50 @@ func (s *lwdStreamer) GetTransaction(ctx context.Context, txf *walletrpc.TxFilte
 		if err != nil {
 			return nil, err
 		}
+		// The cache's index follows reorgs, so prefer its idea of the height.
+		height := txinfo.Height
+		if h, _ := s.cache.GetTxLocation(txf.Hash); h >= 0 {
+			height = h
+		}
 		return &walletrpc.RawTransaction{
 			Data:   txBytes,
-			Height: uint64(txinfo.Height),
+			Height: uint64(height),
 		}, nil
 	}
 
-	if txf.Block != nil && txf.Block.Hash != nil {
-		return nil, errors.New("Can't GetTransaction with a blockhash+num. Please call GetTransaction with txid")
+	if txf.Block != nil {
+		if txf.Block.Height == 0 && txf.Block.Hash == nil {
+			return nil, errors.New("request for unspecified identifier")
+		}
+		if txf.Block.Hash != nil && len(txf.Block.Hash) != 32 {
+			return nil, errors.New("Block hash has invalid length")
+		}
+		return common.GetBlockTransaction(txf.Block, int(txf.Index))
+	}
+	return nil, errors.New("Please call GetTransaction with txid or block and index")
+}
+
+// GetTransactionProof returns the header of the block containing the given
+// transaction and the merkle branch that proves the transaction is in it;
+// see parser.VerifyTransactionProof. The transaction is specified as for
+// GetTransaction.
+func (s *lwdStreamer) GetTransactionProof(ctx context.Context, txf *walletrpc.TxFilter) (*walletrpc.TransactionProof, error) {
+	if txf.Hash != nil {
+		if len(txf.Hash) != 32 {
+			return nil, errors.New("Transaction ID has invalid length")
+		}
+		return common.GetTransactionProof(s.cache, txf.Hash)
+	}
+	if txf.Block != nil {
+		if txf.Block.Height == 0 && txf.Block.Hash == nil {
+			return nil, errors.New("request for unspecified identifier")
+		}
+		if txf.Block.Hash != nil && len(txf.Block.Hash) != 32 {
+			return nil, errors.New("Block hash has invalid length")
+		}
+		return common.GetBlockTransactionProof(txf.Block, int(txf.Index))
 	}
-	return nil, errors.New("Please call GetTransaction with txid")
+	return nil, errors.New("Please call GetTransactionProof with txid or block and index")
 }
 
 // GetLightdInfo gets the LightWalletD (this server) info, and includes information
@@ -302,6 +506,10 @@ func (s *lwdStreamer) SendTransaction(ctx context.Context, rawtx *walletrpc.RawT
 		return nil, errors.New("Bad transaction data")
 	}
 
+	if err := common.NodeUnavailable(); err != nil {
+		return nil, err
+	}
+
 	// Construct raw JSON-RPC params
 	params := make([]json.RawMessage, 1)
 	txJSON, err := json.Marshal(hex.EncodeToString(rawtx.Data))
@@ -339,11 +547,13 @@ func (s *lwdStreamer) SendTransaction(ctx context.Context, rawtx *walletrpc.RawT
 	}, nil
 }
 
-func getTaddressBalanceZcashdRpc(addressList []string) (*walletrpc.Balance, error) {
-	for _, addr := range addressList {
-		if err := checkTaddress(addr); err != nil {
-			return &walletrpc.Balance{}, err
-		}
+func getTaddressBalanceZcashdRpc(cache *common.BlockCache, addressList []string) (*walletrpc.Balance, error) {
+	addressList, err := resolveAddresses(cache, addressList)
+	if err != nil {
+		return &walletrpc.Balance{}, err
+	}
+	if err := common.NodeUnavailable(); err != nil {
+		return &walletrpc.Balance{}, err
 	}
 	params := make([]json.RawMessage, 1)
 	addrList := &common.ZcashdRpcRequestGetaddressbalance{
@@ -364,12 +574,16 @@ func getTaddressBalanceZcashdRpc(addressList []string) (*walletrpc.Balance, erro
 	if err != nil {
 		return &walletrpc.Balance{}, err
 	}
-	return &walletrpc.Balance{ValueZat: balanceReply.Balance}, nil
+	currencyValues, err := common.CurrencyValues(balanceReply.CurrencyBalance)
+	if err != nil {
+		return &walletrpc.Balance{}, err
+	}
+	return &walletrpc.Balance{ValueZat: balanceReply.Balance, CurrencyValues: currencyValues}, nil
 }
 
 // GetTaddressBalance returns the total balance for a list of taddrs
 func (s *lwdStreamer) GetTaddressBalance(ctx context.Context, addresses *walletrpc.AddressList) (*walletrpc.Balance, error) {
-	return getTaddressBalanceZcashdRpc(addresses.Addresses)
+	return getTaddressBalanceZcashdRpc(s.cache, addresses.Addresses)
 }
 
 // GetTaddressBalanceStream returns the total balance for a list of taddrs
@@ -385,7 +599,7 @@ func (s *lwdStreamer) GetTaddressBalanceStream(addresses walletrpc.CompactTxStre
 		}
 		addressList = append(addressList, addr.Address)
 	}
-	balance, err := getTaddressBalanceZcashdRpc(addressList)
+	balance, err := getTaddressBalanceZcashdRpc(s.cache, addressList)
 	if err != nil {
 		return err
 	}
@@ -394,21 +608,84 @@ func (s *lwdStreamer) GetTaddressBalanceStream(addresses walletrpc.CompactTxStre
 }
 
 func (s *lwdStreamer) GetMempoolStream(_empty *walletrpc.Empty, resp walletrpc.CompactTxStreamer_GetMempoolStreamServer) error {
+	if err := common.NodeUnavailable(); err != nil {
+		return err
+	}
 	err := common.GetMempool(func(tx *walletrpc.RawTransaction) error {
 		return resp.Send(tx)
 	})
 	return err
 }
 
-func getAddressUtxos(arg *walletrpc.GetAddressUtxosArg, f func(*walletrpc.GetAddressUtxosReply) error) error {
-	for _, a := range arg.Addresses {
-		if err := checkTaddress(a); err != nil {
+// GetMempoolTx streams the compact mempool transactions the client doesn't
+// already have, with the parts from the requested pools (by default, as
+// for blocks, only Sapling).
+func (s *lwdStreamer) GetMempoolTx(exclude *walletrpc.Exclude, resp walletrpc.CompactTxStreamer_GetMempoolTxServer) error {
+	transparent, sapling, err := blockPools(exclude.PoolTypes)
+	if err != nil {
+		return err
+	}
+	if err := common.NodeUnavailable(); err != nil {
+		return err
+	}
+	txs, err := common.MempoolTransactions(exclude.Txid)
+	if err != nil {
+		return err
+	}
+	// (FilterCompact works on blocks.)
+	mempool := &walletrpc.CompactBlock{Vtx: make([]*walletrpc.CompactTx, len(txs))}
+	for i, tx := range txs {
+		mempool.Vtx[i] = tx.ToCompact(0)
+	}
+	for _, ctx := range parser.FilterCompact(mempool, transparent, sapling).Vtx {
+		if err := resp.Send(ctx); err != nil {
 			return err
 		}
 	}
+	return nil
+}
+
+// GetMempoolEvents streams the transactions in the mempool, then its changes
+// (transactions added, and mined, expired, replaced by a conflicting one or
+// otherwise removed) until the client cancels. A client that falls too far behind is
+// disconnected.
+func (s *lwdStreamer) GetMempoolEvents(_empty *walletrpc.Empty, resp walletrpc.CompactTxStreamer_GetMempoolEventsServer) error {
+	if err := common.NodeUnavailable(); err != nil {
+		return err
+	}
+	sub, current := common.SubscribeMempoolEvents(s.cache)
+	defer sub.Close()
+	for _, event := range current {
+		if err := resp.Send(event); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-resp.Context().Done():
+			return resp.Context().Err()
+		case event, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "Mempool event subscriber fell too far behind")
+			}
+			if err := resp.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func getAddressUtxos(cache *common.BlockCache, arg *walletrpc.GetAddressUtxosArg, f func(*walletrpc.GetAddressUtxosReply) error) error {
+	addresses, err := resolveAddresses(cache, arg.Addresses)
+	if err != nil {
+		return err
+	}
+	if err := common.NodeUnavailable(); err != nil {
+		return err
+	}
 	params := make([]json.RawMessage, 1)
 	addrList := &common.ZcashdRpcRequestGetaddressutxos{
-		Addresses: arg.Addresses,
+		Addresses: addresses,
 	}
 	param, err := json.Marshal(addrList)
 	if err != nil {
@@ -441,13 +718,18 @@ func getAddressUtxos(arg *walletrpc.GetAddressUtxosArg, f func(*walletrpc.GetAdd
 		if err != nil {
 			return err
 		}
+		currencyValues, err := common.CurrencyValues(utxo.CurrencyValues)
+		if err != nil {
+			return err
+		}
 		err = f(&walletrpc.GetAddressUtxosReply{
-			Address:  utxo.Address,
-			Txid:     parser.Reverse(txidBytes),
-			Index:    int32(utxo.OutputIndex),
-			Script:   scriptBytes,
-			ValueZat: int64(utxo.Satoshis),
-			Height:   uint64(utxo.Height),
+			Address:        utxo.Address,
+			Txid:           parser.Reverse(txidBytes),
+			Index:          int32(utxo.OutputIndex),
+			Script:         scriptBytes,
+			ValueZat:       int64(utxo.Satoshis),
+			Height:         uint64(utxo.Height),
+			CurrencyValues: currencyValues,
 		})
 		if err != nil {
 			return err
@@ -458,7 +740,7 @@ func getAddressUtxos(arg *walletrpc.GetAddressUtxosArg, f func(*walletrpc.GetAdd
 
 func (s *lwdStreamer) GetAddressUtxos(ctx context.Context, arg *walletrpc.GetAddressUtxosArg) (*walletrpc.GetAddressUtxosReplyList, error) {
 	addressUtxos := make([]*walletrpc.GetAddressUtxosReply, 0)
-	err := getAddressUtxos(arg, func(utxo *walletrpc.GetAddressUtxosReply) error {
+	err := getAddressUtxos(s.cache, arg, func(utxo *walletrpc.GetAddressUtxosReply) error {
 		addressUtxos = append(addressUtxos, utxo)
 		return nil
 	})
@@ -469,7 +751,7 @@ func (s *lwdStreamer) GetAddressUtxos(ctx context.Context, arg *walletrpc.GetAdd
 }
 
 func (s *lwdStreamer) GetAddressUtxosStream(arg *walletrpc.GetAddressUtxosArg, resp walletrpc.CompactTxStreamer_GetAddressUtxosStreamServer) error {
-	err := getAddressUtxos(arg, func(utxo *walletrpc.GetAddressUtxosReply) error {
+	err := getAddressUtxos(s.cache, arg, func(utxo *walletrpc.GetAddressUtxosReply) error {
 		return resp.Send(utxo)
 	})
 	if err != nil {
@@ -478,6 +760,379 @@ func (s *lwdStreamer) GetAddressUtxosStream(arg *walletrpc.GetAddressUtxosArg, r
 	return nil
 }
 
+func identityToProto(id *common.ZcashdRpcIdentity) *walletrpc.Identity {
+	return &walletrpc.Identity{
+		Version:             id.Version,
+		Flags:               id.Flags,
+		PrimaryAddresses:    id.PrimaryAddresses,
+		MinimumSignatures:   id.MinimumSignatures,
+		Name:                id.Name,
+		IdentityAddress:     id.IdentityAddress,
+		Parent:              id.Parent,
+		SystemId:            id.SystemID,
+		ContentMap:          id.ContentMap,
+		RevocationAuthority: id.RevocationAuthority,
+		RecoveryAuthority:   id.RecoveryAuthority,
+		PrivateAddress:      id.PrivateAddress,
+		TimeLock:            id.TimeLock,
+	}
+}
+
+func identityFromProto(id *walletrpc.Identity) common.ZcashdRpcIdentity {
+	return common.ZcashdRpcIdentity{
+		Version:             id.GetVersion(),
+		Flags:               id.GetFlags(),
+		PrimaryAddresses:    id.GetPrimaryAddresses(),
+		MinimumSignatures:   id.GetMinimumSignatures(),
+		Name:                id.GetName(),
+		IdentityAddress:     id.GetIdentityAddress(),
+		Parent:              id.GetParent(),
+		SystemID:            id.GetSystemId(),
+		ContentMap:          id.GetContentMap(),
+		RevocationAuthority: id.GetRevocationAuthority(),
+		RecoveryAuthority:   id.GetRecoveryAuthority(),
+		PrivateAddress:      id.GetPrivateAddress(),
+		TimeLock:            id.GetTimeLock(),
+	}
+}
+
+// GetIdentity returns the given VerusID (by name or i-address), as of the
+// latest block or the given height.
+func (s *lwdStreamer) GetIdentity(ctx context.Context, req *walletrpc.IdentityRequest) (*walletrpc.IdentityInfo, error) {
+	reply, err := common.GetIdentity(s.cache, req.Identity, req.Height)
+	if err != nil {
+		return nil, err
+	}
+	txid, err := hex.DecodeString(reply.Txid)
+	if err != nil {
+		return nil, err
+	}
+	return &walletrpc.IdentityInfo{
+		Identity:           identityToProto(&reply.Identity),
+		Status:             reply.Status,
+		CanSpendFor:        reply.CanSpendFor,
+		CanSignFor:         reply.CanSignFor,
+		BlockHeight:        uint64(reply.BlockHeight),
+		Txid:               parser.Reverse(txid),
+		Vout:               reply.Vout,
+		FullyQualifiedName: reply.FullyQualifiedName,
+	}, nil
+}
+
+// GetIdentityHistory returns the versions of the given VerusID that were
+// defined within the given block range.
+func (s *lwdStreamer) GetIdentityHistory(ctx context.Context, req *walletrpc.IdentityHistoryRequest) (*walletrpc.IdentityHistory, error) {
+	reply, err := common.GetIdentityHistory(s.cache, req.Identity, req.StartHeight, req.EndHeight)
+	if err != nil {
+		return nil, err
+	}
+	history := &walletrpc.IdentityHistory{
+		FullyQualifiedName: reply.FullyQualifiedName,
+		Status:             reply.Status,
+	}
+	for i := range reply.History {
+		entry := &reply.History[i]
+		blockHash, err := hex.DecodeString(entry.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		txid, err := hex.DecodeString(entry.Output.Txid)
+		if err != nil {
+			return nil, err
+		}
+		history.History = append(history.History, &walletrpc.IdentityHistoryEntry{
+			Identity:  identityToProto(&entry.Identity),
+			Height:    uint64(entry.Height),
+			BlockHash: parser.Reverse(blockHash),
+			Txid:      parser.Reverse(txid),
+			Vout:      entry.Output.VoutNum,
+		})
+	}
+	return history, nil
+}
+
+// amount converts an amount from the node (in coins) to satoshis; a missing
+// amount is zero.
+func amount(coins json.Number) (int64, error) {
+	if coins == "" {
+		return 0, nil
+	}
+	return common.CoinsToSatoshis(coins)
+}
+
+func amounts(coins []json.Number) ([]int64, error) {
+	satoshis := make([]int64, len(coins))
+	for i, c := range coins {
+		var err error
+		if satoshis[i], err = amount(c); err != nil {
+			return nil, err
+		}
+	}
+	return satoshis, nil
+}
+
+func currencyStateToProto(cs *common.ZcashdRpcCurrencyState, height uint64) (*walletrpc.CurrencyState, error) {
+	var err error
+	state := &walletrpc.CurrencyState{
+		CurrencyId: cs.CurrencyID,
+		Flags:      cs.Flags,
+		Height:     height,
+	}
+	if state.Supply, err = amount(cs.Supply); err != nil {
+		return nil, err
+	}
+	if state.InitialSupply, err = amount(cs.InitialSupply); err != nil {
+		return nil, err
+	}
+	if state.Emitted, err = amount(cs.Emitted); err != nil {
+		return nil, err
+	}
+	for _, rc := range cs.ReserveCurrencies {
+		reserve := &walletrpc.ReserveCurrency{CurrencyId: rc.CurrencyID}
+		if reserve.Weight, err = amount(rc.Weight); err != nil {
+			return nil, err
+		}
+		if reserve.Reserves, err = amount(rc.Reserves); err != nil {
+			return nil, err
+		}
+		if reserve.PriceInReserve, err = amount(rc.PriceInReserve); err != nil {
+			return nil, err
+		}
+		state.ReserveCurrencies = append(state.ReserveCurrencies, reserve)
+	}
+	return state, nil
+}
+
+func currencyToProto(c *common.ZcashdRpcReplyGetcurrency) (*walletrpc.CurrencyDefinition, error) {
+	var err error
+	def := &walletrpc.CurrencyDefinition{
+		Version:              c.Version,
+		Options:              c.Options,
+		Name:                 c.Name,
+		FullyQualifiedName:   c.FullyQualifiedName,
+		CurrencyId:           c.CurrencyID,
+		Parent:               c.Parent,
+		SystemId:             c.SystemID,
+		LaunchSystemId:       c.LaunchSystemID,
+		NotarizationProtocol: c.NotarizationProtocol,
+		ProofProtocol:        c.ProofProtocol,
+		StartBlock:           c.StartBlock,
+		EndBlock:             c.EndBlock,
+		Currencies:           c.Currencies,
+		IdReferralLevels:     c.IDReferralLevels,
+		Notaries:             c.Notaries,
+		MinNotariesConfirm:   c.MinNotariesConfirm,
+	}
+	if def.Weights, err = amounts(c.Weights); err != nil {
+		return nil, err
+	}
+	if def.Conversions, err = amounts(c.Conversions); err != nil {
+		return nil, err
+	}
+	if def.InitialSupply, err = amount(c.InitialSupply); err != nil {
+		return nil, err
+	}
+	if def.IdRegistrationFees, err = amount(c.IDRegistrationFees); err != nil {
+		return nil, err
+	}
+	if def.IdImportFees, err = amount(c.IDImportFees); err != nil {
+		return nil, err
+	}
+	if c.BestCurrencyState != nil {
+		if def.BestCurrencyState, err = currencyStateToProto(c.BestCurrencyState, c.BestHeight); err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
+func coins(satoshis []int64) []json.Number {
+	amounts := make([]json.Number, len(satoshis))
+	for i, s := range satoshis {
+		amounts[i] = common.SatoshisToCoins(s)
+	}
+	return amounts
+}
+
+// currencyFromProto is the inverse of currencyToProto.
+func currencyFromProto(def *walletrpc.CurrencyDefinition) common.ZcashdRpcReplyGetcurrency {
+	c := common.ZcashdRpcReplyGetcurrency{
+		Version:              def.Version,
+		Options:              def.Options,
+		Name:                 def.Name,
+		FullyQualifiedName:   def.FullyQualifiedName,
+		CurrencyID:           def.CurrencyId,
+		Parent:               def.Parent,
+		SystemID:             def.SystemId,
+		LaunchSystemID:       def.LaunchSystemId,
+		NotarizationProtocol: def.NotarizationProtocol,
+		ProofProtocol:        def.ProofProtocol,
+		StartBlock:           def.StartBlock,
+		EndBlock:             def.EndBlock,
+		Currencies:           def.Currencies,
+		Weights:              coins(def.Weights),
+		Conversions:          coins(def.Conversions),
+		InitialSupply:        common.SatoshisToCoins(def.InitialSupply),
+		IDRegistrationFees:   common.SatoshisToCoins(def.IdRegistrationFees),
+		IDReferralLevels:     def.IdReferralLevels,
+		IDImportFees:         common.SatoshisToCoins(def.IdImportFees),
+		Notaries:             def.Notaries,
+		MinNotariesConfirm:   def.MinNotariesConfirm,
+	}
+	if cs := def.BestCurrencyState; cs != nil {
+		c.BestHeight = cs.Height
+		c.BestCurrencyState = &common.ZcashdRpcCurrencyState{
+			Flags:         cs.Flags,
+			CurrencyID:    cs.CurrencyId,
+			Supply:        common.SatoshisToCoins(cs.Supply),
+			InitialSupply: common.SatoshisToCoins(cs.InitialSupply),
+			Emitted:       common.SatoshisToCoins(cs.Emitted),
+		}
+		for _, rc := range cs.ReserveCurrencies {
+			c.BestCurrencyState.ReserveCurrencies = append(c.BestCurrencyState.ReserveCurrencies,
+				common.ZcashdRpcReserveCurrency{
+					CurrencyID:     rc.CurrencyId,
+					Weight:         common.SatoshisToCoins(rc.Weight),
+					Reserves:       common.SatoshisToCoins(rc.Reserves),
+					PriceInReserve: common.SatoshisToCoins(rc.PriceInReserve),
+				})
+		}
+	}
+	return c
+}
+
+// GetCurrency returns the definition of the given currency (by name or
+// i-address), as of the latest block.
+func (s *lwdStreamer) GetCurrency(ctx context.Context, req *walletrpc.CurrencyRequest) (*walletrpc.CurrencyDefinition, error) {
+	reply, err := common.GetCurrency(s.cache, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return currencyToProto(reply)
+}
+
+// ListCurrencies returns the definitions of the selected currencies, as of
+// the latest block.
+func (s *lwdStreamer) ListCurrencies(ctx context.Context, req *walletrpc.ListCurrenciesRequest) (*walletrpc.CurrencyList, error) {
+	reply, err := common.ListCurrencies(s.cache, &common.ZcashdRpcRequestListcurrencies{
+		LaunchState: req.LaunchState,
+		SystemType:  req.SystemType,
+		FromSystem:  req.FromSystem,
+	})
+	if err != nil {
+		return nil, err
+	}
+	list := &walletrpc.CurrencyList{}
+	for i := range reply {
+		entry := &reply[i]
+		entry.CurrencyDefinition.BestHeight = entry.BestHeight
+		entry.CurrencyDefinition.BestCurrencyState = entry.BestCurrencyState
+		def, err := currencyToProto(&entry.CurrencyDefinition)
+		if err != nil {
+			return nil, err
+		}
+		list.Currencies = append(list.Currencies, def)
+	}
+	return list, nil
+}
+
+// GetCurrencyState returns the state of the given currency at the given
+// height (or the latest block).
+func (s *lwdStreamer) GetCurrencyState(ctx context.Context, req *walletrpc.CurrencyStateRequest) (*walletrpc.CurrencyState, error) {
+	reply, height, err := common.GetCurrencyState(s.cache, req.Currency, req.Height)
+	if err != nil {
+		return nil, err
+	}
+	return currencyStateToProto(reply, height)
+}
+
+// EstimateConversion returns the node's estimate of the result of a currency
+// conversion.
+func (s *lwdStreamer) EstimateConversion(ctx context.Context, req *walletrpc.ConversionRequest) (*walletrpc.ConversionEstimate, error) {
+	if req.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	reply, err := common.EstimateConversion(s.cache, &common.ZcashdRpcRequestEstimateconversion{
+		Currency:   req.Currency,
+		ConvertTo:  req.ConvertTo,
+		Via:        req.Via,
+		Amount:     common.SatoshisToCoins(req.Amount),
+		PreConvert: req.PreConvert,
+	})
+	if err != nil {
+		return nil, err
+	}
+	estimate := &walletrpc.ConversionEstimate{}
+	if estimate.EstimatedCurrencyOut, err = amount(reply.EstimatedCurrencyOut); err != nil {
+		return nil, err
+	}
+	if estimate.NetInputAmount, err = amount(reply.NetInputAmount); err != nil {
+		return nil, err
+	}
+	if reply.EstimatedCurrencyState != nil {
+		if estimate.EstimatedCurrencyState, err = currencyStateToProto(reply.EstimatedCurrencyState, 0); err != nil {
+			return nil, err
+		}
+	}
+	return estimate, nil
+}
+
+// currencyAddress returns the i-address of the given currency ID, or "" if
+// there is none.
+func currencyAddress(id []byte) string {
+	if id == nil {
+		return ""
+	}
+	return encodeAddress(identityVersion, id)
+}
+
+func reserveTransferToProto(t *common.PendingReserveTransfer) *walletrpc.ReserveTransfer {
+	rt := t.Transfer
+	transfer := &walletrpc.ReserveTransfer{
+		Txid:            t.Txid,
+		Vout:            t.Vout,
+		Height:          uint64(t.Height),
+		Flags:           rt.Flags,
+		Values:          make(map[string]int64, len(rt.Values)),
+		FeeCurrencyId:   currencyAddress(rt.FeeCurrencyID),
+		Fees:            rt.Fees,
+		DestCurrencyId:  currencyAddress(rt.DestCurrencyID),
+		SecondReserveId: currencyAddress(rt.SecondReserveID),
+		DestSystemId:    currencyAddress(rt.DestSystemID),
+		DestinationType: uint32(rt.Destination.Type),
+		Destination:     transferDestinationAddress(&rt.Destination),
+	}
+	for _, v := range rt.Values {
+		transfer.Values[currencyAddress(v.CurrencyID)] += v.Amount
+	}
+	return transfer
+}
+
+// GetPendingReserveTransfers returns the reserve transfers to the given
+// address (an R-, b- or i-address, or VerusID name) in the mempool and the
+// latest few blocks.
+func (s *lwdStreamer) GetPendingReserveTransfers(ctx context.Context, addr *walletrpc.Address) (*walletrpc.ReserveTransferList, error) {
+	resolved, err := resolveAddress(s.cache, addr.Address)
+	if err != nil {
+		return nil, err
+	}
+	version, hash, err := decodeAddress(resolved)
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := common.GetPendingReserveTransfers(s.cache)
+	if err != nil {
+		return nil, err
+	}
+	list := &walletrpc.ReserveTransferList{}
+	for i := range transfers {
+		if transferDestinationMatches(&transfers[i].Transfer.Destination, version, hash) {
+			list.Transfers = append(list.Transfers, reserveTransferToProto(&transfers[i]))
+		}
+	}
+	return list, nil
+}
+
 // This rpc is used only for testing.
 var concurrent int64
 
@@ -607,6 +1262,12 @@ func (s *DarksideStreamer) AddAddressUtxo(ctx context.Context, arg *walletrpc.Ge
 		Satoshis:    uint64(arg.ValueZat),
 		Height:      int(arg.Height),
 	}
+	if len(arg.CurrencyValues) > 0 {
+		utxosReply.CurrencyValues = make(map[string]json.Number)
+		for currency, value := range arg.CurrencyValues {
+			utxosReply.CurrencyValues[currency] = common.SatoshisToCoins(value)
+		}
+	}
 	err := common.DarksideAddAddressUtxo(utxosReply)
 	return &walletrpc.Empty{}, err
 }
@@ -616,3 +1277,39 @@ func (s *DarksideStreamer) ClearAddressUtxo(ctx context.Context, arg *walletrpc.
 	err := common.DarksideClearAddressUtxos()
 	return &walletrpc.Empty{}, err
 }
+
+// StageIdentity adds a version of an identity, which will be returned by
+// GetIdentity() and GetIdentityHistory() (above) once the active chain
+// reaches its blockHeight.
+func (s *DarksideStreamer) StageIdentity(ctx context.Context, arg *walletrpc.IdentityInfo) (*walletrpc.Empty, error) {
+	err := common.DarksideStageIdentity(common.ZcashdRpcReplyGetidentity{
+		FullyQualifiedName: arg.FullyQualifiedName,
+		Identity:           identityFromProto(arg.Identity),
+		Status:             arg.Status,
+		CanSpendFor:        arg.CanSpendFor,
+		CanSignFor:         arg.CanSignFor,
+		BlockHeight:        int(arg.BlockHeight),
+		Txid:               hex.EncodeToString(parser.Reverse(arg.Txid)),
+		Vout:               arg.Vout,
+	})
+	return &walletrpc.Empty{}, err
+}
+
+// ClearIdentities removes the list of staged identities
+func (s *DarksideStreamer) ClearIdentities(ctx context.Context, arg *walletrpc.Empty) (*walletrpc.Empty, error) {
+	err := common.DarksideClearIdentities()
+	return &walletrpc.Empty{}, err
+}
+
+// StageCurrency adds a currency definition, which will be returned by
+// GetCurrency(), ListCurrencies() and GetCurrencyState() (above).
+func (s *DarksideStreamer) StageCurrency(ctx context.Context, arg *walletrpc.CurrencyDefinition) (*walletrpc.Empty, error) {
+	err := common.DarksideStageCurrency(currencyFromProto(arg))
+	return &walletrpc.Empty{}, err
+}
+
+// ClearCurrencies removes the list of staged currencies
+func (s *DarksideStreamer) ClearCurrencies(ctx context.Context, arg *walletrpc.Empty) (*walletrpc.Empty, error) {
+	err := common.DarksideClearCurrencies()
+	return &walletrpc.Empty{}, err
+}
@@ -6,6 +6,7 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/asherda/lightwalletd/parser/internal/bytestring"
@@ -42,6 +43,95 @@ func (b *Block) Transactions() []*Transaction {
 	return b.vtx
 }
 
+// GetTime returns the block's timestamp (Unix epoch time).
+func (b *Block) GetTime() uint32 {
+	return b.hdr.Time
+}
+
+// CheckMerkleRoot returns an error if the header's merkle root doesn't match
+// the block's transactions, or if it would match them with some of them
+// removed (a block's transactions can be duplicated without changing its
+// merkle root, and so its hash).
+func (b *Block) CheckMerkleRoot() error {
+	root, mutated := merkleRoot(b.txHashes())
+	if !bytes.Equal(root, b.hdr.HashMerkleRoot) {
+		return errors.New("merkle root doesn't match the transactions")
+	}
+	if mutated {
+		return errors.New("block has duplicated transactions (mutated merkle tree)")
+	}
+	return nil
+}
+
+// CheckProofOfWork returns an error if the block hash doesn't meet the
+// target in the header.
+func (b *Block) CheckProofOfWork() error {
+	return b.hdr.CheckProofOfWork()
+}
+
+// CheckProofOfStake checks what can be checked of a Verus proof-of-stake
+// block without the chain state: its header is marked as proof of stake, and
+// it has a coinbase and, last, a stake transaction that spends a single
+// output to a single output. Whether the stake is eligible (its value and age
+// against the target) isn't checked.
+func (b *Block) CheckProofOfStake() error {
+	if !b.hdr.IsProofOfStake() {
+		return errors.New("block isn't marked as proof of stake")
+	}
+	if len(b.vtx) < 2 || !b.vtx[0].IsCoinbase() {
+		return errors.New("proof-of-stake block has no stake transaction")
+	}
+	stake := b.vtx[len(b.vtx)-1]
+	if stake.IsCoinbase() || len(stake.transparentInputs) != 1 || len(stake.transparentOutputs) != 1 {
+		return errors.New("invalid stake transaction")
+	}
+	return nil
+}
+
+// MerkleRoot computes the root of the merkle tree of the block's
+// transactions, in little-endian wire order (as in the header).
+func (b *Block) MerkleRoot() []byte {
+	root, _ := merkleRoot(b.txHashes())
+	return root
+}
+
+// txHashes returns the block's txids, in little-endian wire order.
+func (b *Block) txHashes() [][]byte {
+	hashes := make([][]byte, len(b.vtx))
+	for i, tx := range b.vtx {
+		hashes[i] = tx.GetEncodableHash()
+	}
+	return hashes
+}
+
+// TransactionProof returns the block header and the merkle branch that prove
+// the transaction at the given index is in the block.
+func (b *Block) TransactionProof(index int) (*walletrpc.TransactionProof, error) {
+	if index < 0 || index >= len(b.vtx) {
+		return nil, errors.New("transaction index is out of range")
+	}
+	header, err := b.hdr.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	hashes := b.txHashes()
+	return &walletrpc.TransactionProof{
+		Header: header,
+		Height: uint64(b.GetHeight()),
+		Txid:   hashes[index],
+		Index:  uint32(index),
+		Branch: merkleBranch(hashes, index),
+	}, nil
+}
+
+// AppendTransactions adds the given transactions to the end of the block
+// and updates the header's merkle root (and so the block hash) to match.
+func (b *Block) AppendTransactions(txs ...*Transaction) {
+	b.vtx = append(b.vtx, txs...)
+	b.hdr.HashMerkleRoot = b.MerkleRoot()
+	b.hdr.cachedHash = nil
+}
+
 // GetDisplayHash returns the block hash in big-endian display order.
 func (b *Block) GetDisplayHash() []byte {
 	return b.hdr.GetDisplayHash()
@@ -105,27 +195,94 @@ func (b *Block) GetPrevHash() []byte {
 	return b.hdr.HashPrevBlock
 }
 
-// ToCompact returns the compact representation of the full block.
+// CompactBlockVersion is the CompactBlock.protoVersion of the blocks made by
+// ToCompact. Version 1 added the transparent inputs and outputs (and the
+// transactions that have only those), version 2 the fees of transactions
+// with transparent inputs, version 3 the full (serialized) header, which
+// ToCompact leaves out (see MarshalHeader).
+const CompactBlockVersion = 3
+
+// ToCompact returns the compact representation of the full block, with all
+// of its transactions, including their transparent inputs and outputs. Most
+// clients want only some of these; see FilterCompact.
 func (b *Block) ToCompact() *walletrpc.CompactBlock {
+	return b.ToCompactWithFees(nil)
+}
+
+// ToCompactWithFees is ToCompact, but the fees of transactions that spend
+// outputs of earlier blocks' transactions are found using prevoutValue
+// (the values of the outputs of this block's transactions are known).
+func (b *Block) ToCompactWithFees(prevoutValue PrevoutValue) *walletrpc.CompactBlock {
 	compactBlock := &walletrpc.CompactBlock{
-		//TODO ProtoVersion: 1,
-		Height:   uint64(b.GetHeight()),
-		PrevHash: b.hdr.HashPrevBlock,
-		Hash:     b.GetEncodableHash(),
-		Time:     b.hdr.Time,
+		ProtoVersion: CompactBlockVersion,
+		Height:       uint64(b.GetHeight()),
+		PrevHash:     b.hdr.HashPrevBlock,
+		Hash:         b.GetEncodableHash(),
+		Time:         b.hdr.Time,
 	}
-
-	// Only Sapling transactions have a meaningful compact encoding
-	saplingTxns := make([]*walletrpc.CompactTx, 0, len(b.vtx))
+	type outpoint struct {
+		txid  string
+		index uint32
+	}
+	outputs := make(map[outpoint]uint64)
+	blockPrevoutValue := func(txid []byte, index uint32) (uint64, bool) {
+		if value, ok := outputs[outpoint{string(txid), index}]; ok {
+			return value, true
+		}
+		if prevoutValue == nil {
+			return 0, false
+		}
+		return prevoutValue(txid, index)
+	}
+	compactBlock.Vtx = make([]*walletrpc.CompactTx, len(b.vtx))
 	for idx, tx := range b.vtx {
-		if tx.HasSaplingElements() {
-			saplingTxns = append(saplingTxns, tx.ToCompact(idx))
+		ctx := tx.ToCompact(idx)
+		if len(tx.transparentInputs) > 0 {
+			ctx.Fee = tx.compactFee(blockPrevoutValue)
+		}
+		for i, txout := range tx.transparentOutputs {
+			outputs[outpoint{string(ctx.Hash), uint32(i)}] = txout.Value
 		}
+		compactBlock.Vtx[idx] = ctx
 	}
-	compactBlock.Vtx = saplingTxns
 	return compactBlock
 }
 
+// FilterCompact returns the parts of the given compact block (as made by
+// ToCompact) from the selected pools: transparent inputs and outputs, and
+// Sapling spends and outputs. Transactions with nothing from those pools are
+// left out, so a Sapling-only block is as it was before version 1.
+func FilterCompact(block *walletrpc.CompactBlock, transparent, sapling bool) *walletrpc.CompactBlock {
+	filtered := &walletrpc.CompactBlock{
+		ProtoVersion: block.ProtoVersion,
+		Height:       block.Height,
+		Hash:         block.Hash,
+		PrevHash:     block.PrevHash,
+		Time:         block.Time,
+		Header:       block.Header,
+		Vtx:          make([]*walletrpc.CompactTx, 0, len(block.Vtx)),
+	}
+	for _, tx := range block.Vtx {
+		ftx := &walletrpc.CompactTx{
+			Index: tx.Index,
+			Hash:  tx.Hash,
+			Fee:   tx.Fee,
+		}
+		if sapling {
+			ftx.Spends = tx.Spends
+			ftx.Outputs = tx.Outputs
+		}
+		if transparent {
+			ftx.Vin = tx.Vin
+			ftx.Vout = tx.Vout
+		}
+		if len(ftx.Spends)+len(ftx.Outputs)+len(ftx.Vin)+len(ftx.Vout) > 0 {
+			filtered.Vtx = append(filtered.Vtx, ftx)
+		}
+	}
+	return filtered
+}
+
 // ParseFromSlice deserializes a block from the given data stream
 // and returns a slice to the remaining data. The caller should verify
 // there is no remaining data if none is expected.
@@ -160,3 +317,27 @@ func (b *Block) ParseFromSlice(data []byte) (rest []byte, err error) {
 	b.vtx = vtx
 	return data, nil
 }
+
+// MarshalHeader returns the block's serialized header (including the Verus
+// solution), as in CompactBlock.header.
+func (b *Block) MarshalHeader() ([]byte, error) {
+	return b.hdr.MarshalBinary()
+}
+
+// MarshalBinary returns the block (header and transactions) in serialized form.
+func (b *Block) MarshalBinary() ([]byte, error) {
+	hdr, err := b.hdr.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing block header")
+	}
+	buf := bytes.NewBuffer(hdr)
+	WriteCompactLengthPrefixedLen(buf, len(b.vtx))
+	for i, tx := range b.vtx {
+		txBytes, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("serializing transaction %d", i))
+		}
+		buf.Write(txBytes)
+	}
+	return buf.Bytes(), nil
+}
@@ -18,6 +18,8 @@ import (
 const (
 	serBlockHeaderMinusEquihashSize = 140  // size of a serialized block header minus the Equihash solution
 	equihashSizeMainnet             = 1344 // size of a mainnet / testnet Equihash solution in bytes
+
+	verusV2 = 0x00010004 // CBlockHeader::VERUS_V2, the block version hashed with VerusHash 2
 )
 
 // RawBlockHeader implements the block header as defined in version
@@ -169,9 +171,6 @@ func (hdr *BlockHeader) ParseFromSlice(in []byte) (rest []byte, err error) {
 		return in, errors.New("could not read CompactSize-prefixed Equihash solution")
 	}
 
-	// TODO: interpret the bytes
-	//hdr.targetThreshold = parseNBits(hdr.NBitsBytes)
-
 	return []byte(s), nil
 }
 
@@ -194,6 +193,44 @@ func parseNBits(b []byte) *big.Int {
 	return new(big.Int).SetBytes(targetBytes)
 }
 
+// GetTarget returns the target threshold encoded by the header's nBits.
+func (hdr *BlockHeader) GetTarget() *big.Int {
+	// nBits is little-endian on the wire
+	return parseNBits(Reverse(hdr.NBitsBytes))
+}
+
+// CheckProofOfWork returns an error if the header's hash doesn't meet
+// (isn't less than or equal to) its target.
+func (hdr *BlockHeader) CheckProofOfWork() error {
+	target := hdr.GetTarget()
+	if target.Sign() <= 0 {
+		return errors.New("invalid nBits target")
+	}
+	if new(big.Int).SetBytes(hdr.GetDisplayHash()).Cmp(target) > 0 {
+		return errors.New("block hash doesn't meet its target")
+	}
+	return nil
+}
+
+// IsProofOfStake reports whether the header is marked as a Verus
+// proof-of-stake block (CBlockHeader::IsVerusPOSBlock): the first 4 bytes of
+// the nonce are a nonzero stake target, and its high 128 bits are the hash of
+// its low 128 bits.
+func (hdr *BlockHeader) IsProofOfStake() bool {
+	if len(hdr.Nonce) != 32 || binary.LittleEndian.Uint32(hdr.Nonce[:4]) == 0 {
+		return false
+	}
+	lowNonce := make([]byte, 32)
+	copy(lowNonce, hdr.Nonce[:16])
+	var hash []byte
+	if hdr.Version == verusV2 {
+		hash = verushash.VerusHash_V2(lowNonce)
+	} else {
+		hash = verushash.VerusHash(lowNonce)
+	}
+	return bytes.Equal(hdr.Nonce[16:], hash[:16])
+}
+
 // GetDisplayHash returns the bytes of a block hash in big-endian order.
 func (hdr *BlockHeader) GetDisplayHash() []byte {
 	if hdr.cachedHash != nil {
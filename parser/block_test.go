@@ -11,8 +11,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/asherda/lightwalletd/parser/verushash"
 	"github.com/pkg/errors"
 
 	protobuf "github.com/golang/protobuf/proto"
@@ -173,6 +175,229 @@ func TestBlockParserFail(t *testing.T) {
 	}
 }
 
+func TestBlockMarshal(t *testing.T) {
+	testBlocks, err := os.Open("../testdata/blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testBlocks.Close()
+
+	scan := bufio.NewScanner(testBlocks)
+	for blockindex := 0; scan.Scan(); blockindex++ {
+		blockData, err := hex.DecodeString(scan.Text())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Also a block with enough transactions (the originals, repeated)
+		// to need a multi-byte tx count; these blocks' headers are 1487 bytes.
+		bigBlock := append([]byte{}, blockData[:1487]...)
+		txCount := int(blockData[1487]) * 300
+		bigBlock = append(bigBlock, 0xfd, byte(txCount), byte(txCount>>8))
+		for i := 0; i < 300; i++ {
+			bigBlock = append(bigBlock, blockData[1488:]...)
+		}
+		for _, data := range [][]byte{blockData, bigBlock} {
+			block := NewBlock()
+			if rest, err := block.ParseFromSlice(data); err != nil || len(rest) != 0 {
+				t.Fatal("block", blockindex, "parse failed:", err)
+			}
+			marshaled, err := block.MarshalBinary()
+			if err != nil {
+				t.Fatal("block", blockindex, "MarshalBinary failed:", err)
+			}
+			if !bytes.Equal(marshaled, data) {
+				t.Error("block", blockindex, "MarshalBinary did not round-trip")
+			}
+		}
+	}
+}
+
+func TestBlockMerkleRoot(t *testing.T) {
+	testBlocks, err := os.Open("../testdata/blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testBlocks.Close()
+
+	var blocks []*Block
+	scan := bufio.NewScanner(testBlocks)
+	for blockindex := 0; scan.Scan(); blockindex++ {
+		blockData, err := hex.DecodeString(scan.Text())
+		if err != nil {
+			t.Fatal(err)
+		}
+		block := NewBlock()
+		if _, err := block.ParseFromSlice(blockData); err != nil {
+			t.Fatal("block", blockindex, "parse failed:", err)
+		}
+		if !bytes.Equal(block.MerkleRoot(), block.hdr.HashMerkleRoot) {
+			t.Error("block", blockindex, "unexpected merkle root", hex.EncodeToString(block.MerkleRoot()))
+		}
+		blocks = append(blocks, block)
+	}
+
+	// Appending transactions (an odd number, then an even number of them)
+	// keeps the block's merkle root consistent across serialization.
+	block := blocks[0]
+	oldHash := block.GetDisplayHash()
+	for _, txs := range [][]*Transaction{blocks[2].Transactions(), blocks[3].Transactions()[1:]} {
+		block.AppendTransactions(txs...)
+		if bytes.Equal(block.GetDisplayHash(), oldHash) {
+			t.Fatal("AppendTransactions didn't change the block hash")
+		}
+		blockData, err := block.MarshalBinary()
+		if err != nil {
+			t.Fatal("MarshalBinary failed:", err)
+		}
+		reparsed := NewBlock()
+		if rest, err := reparsed.ParseFromSlice(blockData); err != nil || len(rest) != 0 {
+			t.Fatal("reparse failed:", err)
+		}
+		if reparsed.GetTxCount() != block.GetTxCount() ||
+			!bytes.Equal(reparsed.MerkleRoot(), reparsed.hdr.HashMerkleRoot) ||
+			!bytes.Equal(reparsed.GetDisplayHash(), block.GetDisplayHash()) {
+			t.Fatal("appended block didn't round-trip")
+		}
+	}
+	if block.GetTxCount() != 4 {
+		t.Fatal("unexpected transaction count", block.GetTxCount())
+	}
+}
+
+func TestBlockChecks(t *testing.T) {
+	testBlocks, err := os.Open("../testdata/blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testBlocks.Close()
+
+	var blocks []*Block
+	scan := bufio.NewScanner(testBlocks)
+	for scan.Scan() {
+		blockData, _ := hex.DecodeString(scan.Text())
+		block := NewBlock()
+		if _, err := block.ParseFromSlice(blockData); err != nil {
+			t.Fatal(err)
+		}
+		if err := block.CheckMerkleRoot(); err != nil {
+			t.Fatal("CheckMerkleRoot failed:", err)
+		}
+		blocks = append(blocks, block)
+	}
+	// A block's merkle root doesn't change if its last transaction is
+	// duplicated, when there's an odd number of them (or its last pair, when
+	// there's an odd number of pairs), so neither should be accepted.
+	var txs []*Transaction
+	for _, block := range blocks {
+		txs = append(txs, block.vtx...)
+	}
+	for _, tt := range []struct {
+		count, duplicated int
+	}{
+		{3, 1},
+		{6, 2},
+	} {
+		hdr := *blocks[0].hdr
+		mutated := &Block{hdr: &hdr}
+		mutated.AppendTransactions(txs[:tt.count]...)
+		if err := mutated.CheckMerkleRoot(); err != nil {
+			t.Fatal("CheckMerkleRoot failed:", err)
+		}
+		root := mutated.MerkleRoot()
+		mutated.vtx = append(mutated.vtx, txs[tt.count-tt.duplicated:tt.count]...)
+		if !bytes.Equal(mutated.MerkleRoot(), root) {
+			t.Fatal("duplicating ", tt.duplicated, " of ", tt.count, " transactions changed the merkle root")
+		}
+		if mutated.CheckMerkleRoot() == nil {
+			t.Fatal("CheckMerkleRoot should have failed, ", tt.duplicated, " of ", tt.count, " transactions duplicated")
+		}
+	}
+
+	block := blocks[0]
+	block.hdr.HashMerkleRoot = make([]byte, 32)
+	if block.CheckMerkleRoot() == nil {
+		t.Fatal("CheckMerkleRoot should have failed")
+	}
+
+	// These testnet blocks were mined with Equihash, so their VerusHashes
+	// don't meet their targets; any hash meets the largest target.
+	if block.CheckProofOfWork() == nil {
+		t.Fatal("CheckProofOfWork should have failed")
+	}
+	for _, tt := range []struct {
+		nBits string // little-endian
+		valid bool
+	}{
+		{"ffff0021", true},
+		{"01000003", false},
+		{"00008004", false}, // negative
+		{"00000000", false},
+	} {
+		block.hdr.NBitsBytes, _ = hex.DecodeString(tt.nBits)
+		block.hdr.cachedHash = nil
+		if err := block.CheckProofOfWork(); (err == nil) != tt.valid {
+			t.Error("unexpected CheckProofOfWork result", tt.nBits, err)
+		}
+	}
+
+	// None of the blocks are marked as proof of stake, so none pass, though
+	// the third ends with a transaction that could be a stake (and its hash
+	// doesn't meet its target).
+	for i, block := range blocks {
+		if block.hdr.IsProofOfStake() {
+			t.Error("block marked as proof of stake", i)
+		}
+		if block.CheckProofOfStake() == nil {
+			t.Error("CheckProofOfStake should have failed", i)
+		}
+	}
+	if blocks[2].CheckProofOfWork() == nil {
+		t.Fatal("CheckProofOfWork should have failed")
+	}
+
+	// Marked, only the third block ends with a transaction that could be a stake.
+	for i, valid := range []bool{false, false, true, false} {
+		copy(blocks[i].hdr.Nonce[:4], blocks[i].hdr.NBitsBytes) // the stake target
+		markProofOfStake(blocks[i].hdr)
+		if err := blocks[i].CheckProofOfStake(); (err == nil) != valid {
+			t.Error("unexpected CheckProofOfStake result", i, err)
+		}
+	}
+
+	// The nonce is hashed with VerusHash 2 in version 2 blocks.
+	hdr := blocks[2].hdr
+	hdr.Version = verusV2
+	if hdr.IsProofOfStake() {
+		t.Fatal("version 1 nonce accepted in a version 2 block")
+	}
+	markProofOfStake(hdr)
+	if !hdr.IsProofOfStake() {
+		t.Fatal("version 2 block not marked as proof of stake")
+	}
+	hdr.Nonce[0]++
+	if hdr.IsProofOfStake() {
+		t.Fatal("altered nonce accepted")
+	}
+	copy(hdr.Nonce[:4], []byte{0, 0, 0, 0})
+	markProofOfStake(hdr)
+	if hdr.IsProofOfStake() {
+		t.Fatal("nonce without a stake target accepted")
+	}
+}
+
+// markProofOfStake sets the high half of the header's nonce as
+// CPOSNonce::SetPOSTarget does, keeping its low half (and stake target).
+func markProofOfStake(hdr *BlockHeader) {
+	lowNonce := make([]byte, 32)
+	copy(lowNonce, hdr.Nonce[:16])
+	hash := verushash.VerusHash(lowNonce)
+	if hdr.Version == verusV2 {
+		hash = verushash.VerusHash_V2(lowNonce)
+	}
+	copy(hdr.Nonce[16:], hash[:16])
+	hdr.cachedHash = nil
+}
+
 // Checks on the first 20 blocks from mainnet genesis.
 func TestGenesisBlockParser(t *testing.T) {
 	blockFile, err := os.Open("../testdata/mainnet_genesis")
@@ -259,7 +484,8 @@ func TestCompactBlocks(t *testing.T) {
 			t.Error("block and block header prevhash don't match")
 		}
 
-		compact := block.ToCompact()
+		compact := FilterCompact(block.ToCompact(), false, true)
+		compact.ProtoVersion = 0 // the test vectors predate it
 		marshaled, err := protobuf.Marshal(compact)
 		if err != nil {
 			t.Errorf("could not marshal compact testnet block %d", test.BlockHeight)
@@ -273,3 +499,139 @@ func TestCompactBlocks(t *testing.T) {
 	}
 
 }
+
+func TestToCompactTransparent(t *testing.T) {
+	testBlocks, err := os.Open("../testdata/blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testBlocks.Close()
+
+	scan := bufio.NewScanner(testBlocks)
+	for scan.Scan() {
+		blockData, _ := hex.DecodeString(scan.Text())
+		block := NewBlock()
+		if _, err := block.ParseFromSlice(blockData); err != nil {
+			t.Fatal(err)
+		}
+		compact := block.ToCompact()
+		if compact.Header != nil {
+			t.Fatal("unexpected compact block header")
+		}
+		if header, err := block.MarshalHeader(); err != nil || len(header) != block.hdr.getSize() {
+			t.Fatal("unexpected block header ", len(header), err)
+		}
+		if compact.ProtoVersion != CompactBlockVersion || len(compact.Vtx) != block.GetTxCount() {
+			t.Fatal("unexpected compact block ", compact.ProtoVersion, len(compact.Vtx))
+		}
+		for i, tx := range block.Transactions() {
+			ctx := compact.Vtx[i]
+			if ctx.Index != uint64(i) || len(ctx.Vout) != len(tx.transparentOutputs) {
+				t.Fatal("unexpected compact transaction ", i)
+			}
+			// A coinbase's input spends nothing.
+			if tx.IsCoinbase() != (len(ctx.Vin) == 0) {
+				t.Fatal("unexpected compact transaction inputs ", i, len(ctx.Vin))
+			}
+			for j, in := range ctx.Vin {
+				if !bytes.Equal(in.PrevoutTxid, tx.transparentInputs[j].PrevTxHash) ||
+					in.PrevoutIndex != tx.transparentInputs[j].PrevTxOutIndex {
+					t.Fatal("unexpected compact input ", i, j)
+				}
+			}
+		}
+
+		// Sapling only is as ToCompact was before the transparent data.
+		saplingTxns := 0
+		for _, tx := range block.Transactions() {
+			if tx.HasSaplingElements() {
+				saplingTxns++
+			}
+		}
+		sapling := FilterCompact(compact, false, true)
+		if len(sapling.Vtx) != saplingTxns || !bytes.Equal(sapling.Hash, compact.Hash) {
+			t.Fatal("unexpected Sapling-only block ", len(sapling.Vtx), saplingTxns)
+		}
+		for _, tx := range sapling.Vtx {
+			if len(tx.Vin) != 0 || len(tx.Vout) != 0 {
+				t.Fatal("Sapling-only block has transparent data")
+			}
+		}
+		transparent := FilterCompact(compact, true, false)
+		for _, tx := range transparent.Vtx {
+			if len(tx.Spends) != 0 || len(tx.Outputs) != 0 || len(tx.Vin)+len(tx.Vout) == 0 {
+				t.Fatal("unexpected transparent-only transaction ", tx.Index)
+			}
+		}
+		if len(FilterCompact(compact, true, true).Vtx) != len(compact.Vtx) {
+			t.Fatal("all pools should include all transactions")
+		}
+	}
+}
+
+func TestTxOutToCompact(t *testing.T) {
+	hash := "5a4a4bd4a3fed4dd98a1c1adb7ff5e7ad4a05b0e"
+	for _, tc := range []struct {
+		script      string
+		addressHash string
+		scriptHash  bool
+	}{
+		{"76a914" + hash + "88ac", hash, false},             // P2PKH
+		{"a914" + hash + "87", hash, true},                  // P2SH
+		{"76a914" + hash + "88ad", "", false},               // not quite P2PKH
+		{"a914" + hash + "8787", "", false},                 // too long for P2SH
+		{"21" + strings.Repeat("02", 33) + "ac", "", false}, // P2PK
+	} {
+		script, _ := hex.DecodeString(tc.script)
+		out := (&txOut{Value: 5, Script: script}).ToCompact()
+		if out.Value != 5 || hex.EncodeToString(out.AddressHash) != tc.addressHash || out.ScriptHash != tc.scriptHash {
+			t.Fatal("unexpected compact output ", tc.script, out)
+		}
+		if (tc.addressHash == "") != bytes.Equal(out.ScriptPubKey, script) {
+			t.Fatal("unexpected compact output script ", tc.script, out)
+		}
+	}
+}
+
+func TestToCompactWithFees(t *testing.T) {
+	testBlocks, err := os.Open("../testdata/blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testBlocks.Close()
+	scan := bufio.NewScanner(testBlocks)
+	scan.Scan()
+	blockData, _ := hex.DecodeString(scan.Text())
+
+	// Replace the test block's transactions (after the header, at 1487)
+	// with a coinbase, a transaction spending one of its outputs, and one
+	// spending an output of an earlier block.
+	p2pkh := "19" + "76a914" + "5a4a4bd4a3fed4dd98a1c1adb7ff5e7ad4a05b0e" + "88ac"
+	coinbaseHex := "01000000" + "01" + strings.Repeat("00", 32) + "ffffffff" + "00" + "ffffffff" +
+		"02" + "1027000000000000" + p2pkh + "3075000000000000" + p2pkh + "00000000"
+	coinbaseData, _ := hex.DecodeString(coinbaseHex)
+	coinbase := NewTransaction()
+	coinbase.ParseFromSlice(coinbaseData)
+	spendHex := "01000000" + "01" + hex.EncodeToString(coinbase.GetEncodableHash()) + "01000000" + "00" + "ffffffff" +
+		"01" + "1027000000000000" + p2pkh + "00000000" // spends 30000, fee 20000
+	earlierTxid := strings.Repeat("22", 32)
+	spendEarlierHex := "01000000" + "01" + earlierTxid + "00000000" + "00" + "ffffffff" +
+		"01" + "1027000000000000" + p2pkh + "00000000"
+	txs, _ := hex.DecodeString("03" + coinbaseHex + spendHex + spendEarlierHex)
+	block := NewBlock()
+	rest, err := block.ParseFromSlice(append(append([]byte{}, blockData[:1487]...), txs...))
+	if err != nil || len(rest) != 0 {
+		t.Fatal("could not parse block ", err)
+	}
+
+	compact := block.ToCompact()
+	if compact.Vtx[0].Fee != 0 || compact.Vtx[1].Fee != 20000 || compact.Vtx[2].Fee != 0 {
+		t.Fatal("unexpected fees ", compact.Vtx[0].Fee, compact.Vtx[1].Fee, compact.Vtx[2].Fee)
+	}
+	compact = block.ToCompactWithFees(func(txid []byte, index uint32) (uint64, bool) {
+		return 10500, hex.EncodeToString(txid) == earlierTxid && index == 0
+	})
+	if compact.Vtx[1].Fee != 20000 || compact.Vtx[2].Fee != 500 {
+		t.Fatal("unexpected fees ", compact.Vtx[1].Fee, compact.Vtx[2].Fee)
+	}
+}
@@ -0,0 +1,350 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+// Package cc decodes Verus cryptocondition (CC) output scripts, the outputs
+// of smart transactions (identities, currencies, reserve transfers,
+// notarizations, ...).
+//
+// A CC output script (as verusd's MakeMofNCCScript builds it) is
+//
+//	<master params> OP_CHECKCRYPTOCONDITION <params> OP_DROP
+//
+// where each params is a serialized COptCCParams: itself a script of pushes,
+// the first being {version, eval code, m, n}, then n destinations, then data.
+// A script with more than one condition carries the params of the later
+// conditions at the end of the first condition's data.
+package cc
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Script opcodes
+const (
+	opPushData1            = 0x4c
+	opPushData2            = 0x4d
+	opPushData4            = 0x4e
+	op1                    = 0x51
+	op16                   = 0x60
+	opDrop                 = 0x75
+	opCheckCryptoCondition = 0xcc
+)
+
+// Versions of COptCCParams; smart transactions use VersionV3.
+const (
+	VersionV1 = 1
+	VersionV2 = 2
+	VersionV3 = 3
+)
+
+// EvalCode identifies the kind of output, and so the rules it's validated
+// by, and how its data is to be interpreted.
+type EvalCode uint8
+
+// Eval codes, in sync with src/cc/eval.h in verusd.
+const (
+	EvalNone                        EvalCode = 0x00
+	EvalStakeGuard                  EvalCode = 0x01
+	EvalCurrencyDefinition          EvalCode = 0x02
+	EvalNotaryEvidence              EvalCode = 0x03
+	EvalEarnedNotarization          EvalCode = 0x04
+	EvalAcceptedNotarization        EvalCode = 0x05
+	EvalFinalizeNotarization        EvalCode = 0x06
+	EvalCurrencyState               EvalCode = 0x07
+	EvalReserveTransfer             EvalCode = 0x08
+	EvalReserveOutput               EvalCode = 0x09
+	EvalReserveUnused               EvalCode = 0x0a
+	EvalReserveDeposit              EvalCode = 0x0b
+	EvalCrossChainExport            EvalCode = 0x0c
+	EvalCrossChainImport            EvalCode = 0x0d
+	EvalIdentityPrimary             EvalCode = 0x0e
+	EvalIdentityRevoke              EvalCode = 0x0f
+	EvalIdentityRecover             EvalCode = 0x10
+	EvalIdentityCommitment          EvalCode = 0x11
+	EvalIdentityReservation         EvalCode = 0x12
+	EvalFinalizeExport              EvalCode = 0x13
+	EvalFeePool                     EvalCode = 0x14
+	EvalNotarySignature             EvalCode = 0x15
+	EvalIdentityAdvancedReservation EvalCode = 0x16
+)
+
+var evalCodeNames = map[EvalCode]string{
+	EvalNone:                        "EVAL_NONE",
+	EvalStakeGuard:                  "EVAL_STAKEGUARD",
+	EvalCurrencyDefinition:          "EVAL_CURRENCY_DEFINITION",
+	EvalNotaryEvidence:              "EVAL_NOTARY_EVIDENCE",
+	EvalEarnedNotarization:          "EVAL_EARNEDNOTARIZATION",
+	EvalAcceptedNotarization:        "EVAL_ACCEPTEDNOTARIZATION",
+	EvalFinalizeNotarization:        "EVAL_FINALIZE_NOTARIZATION",
+	EvalCurrencyState:               "EVAL_CURRENCYSTATE",
+	EvalReserveTransfer:             "EVAL_RESERVE_TRANSFER",
+	EvalReserveOutput:               "EVAL_RESERVE_OUTPUT",
+	EvalReserveUnused:               "EVAL_RESERVE_UNUSED",
+	EvalReserveDeposit:              "EVAL_RESERVE_DEPOSIT",
+	EvalCrossChainExport:            "EVAL_CROSSCHAIN_EXPORT",
+	EvalCrossChainImport:            "EVAL_CROSSCHAIN_IMPORT",
+	EvalIdentityPrimary:             "EVAL_IDENTITY_PRIMARY",
+	EvalIdentityRevoke:              "EVAL_IDENTITY_REVOKE",
+	EvalIdentityRecover:             "EVAL_IDENTITY_RECOVER",
+	EvalIdentityCommitment:          "EVAL_IDENTITY_COMMITMENT",
+	EvalIdentityReservation:         "EVAL_IDENTITY_RESERVATION",
+	EvalFinalizeExport:              "EVAL_FINALIZE_EXPORT",
+	EvalFeePool:                     "EVAL_FEE_POOL",
+	EvalNotarySignature:             "EVAL_NOTARY_SIGNATURE",
+	EvalIdentityAdvancedReservation: "EVAL_IDENTITY_ADVANCEDRESERVATION",
+}
+
+func (e EvalCode) String() string {
+	if name, ok := evalCodeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("EVAL_0x%02x", uint8(e))
+}
+
+// DestinationType is the kind of a Destination, in sync with
+// COptCCParams::ADDRTYPE_* in verusd.
+type DestinationType uint8
+
+// Destination types
+const (
+	DestInvalid    DestinationType = 0
+	DestPubKey     DestinationType = 1 // compressed public key
+	DestPubKeyHash DestinationType = 2 // key ID (R-address)
+	DestScriptHash DestinationType = 3 // script hash (b-address)
+	DestIdentity   DestinationType = 4 // identity ID (i-address)
+	DestIndex      DestinationType = 5 // index only, not spendable
+	DestQuantum    DestinationType = 6 // hash of a quantum-secure public key
+)
+
+// Destination is one of the keys (or identities, ...) that can fulfill a
+// condition.
+type Destination struct {
+	Type DestinationType
+	// A 33-byte compressed public key for DestPubKey, else a 20-byte hash
+	// (key ID, identity ID, ...).
+	Bytes []byte
+}
+
+// Params is a decoded COptCCParams: one condition's eval code, its m-of-n
+// destinations, and its data (for most eval codes, a serialized object
+// such as an identity or a reserve transfer).
+type Params struct {
+	Version      uint8
+	EvalCode     EvalCode
+	M            uint8 // how many of the destinations must sign
+	N            uint8 // how many destinations there are
+	Destinations []Destination
+	Data         [][]byte
+}
+
+// Script is a decoded CC output script.
+type Script struct {
+	// Master says how many of the conditions (M) must be fulfilled, and
+	// lists all of the conditions' destinations.
+	Master *Params
+	// Params are the first condition's params.
+	Params *Params
+}
+
+// readOp reads the next opcode from script, returning it along with the
+// data it pushes (if any).
+func readOp(script *[]byte) (byte, []byte, error) {
+	s := *script
+	if len(s) == 0 {
+		return 0, nil, errors.New("script ended unexpectedly")
+	}
+	op := s[0]
+	s = s[1:]
+	var size int
+	switch {
+	case op < opPushData1:
+		size = int(op)
+	case op == opPushData1:
+		if len(s) < 1 {
+			return 0, nil, errors.New("truncated OP_PUSHDATA1")
+		}
+		size = int(s[0])
+		s = s[1:]
+	case op == opPushData2:
+		if len(s) < 2 {
+			return 0, nil, errors.New("truncated OP_PUSHDATA2")
+		}
+		size = int(binary.LittleEndian.Uint16(s))
+		s = s[2:]
+	case op == opPushData4:
+		if len(s) < 4 {
+			return 0, nil, errors.New("truncated OP_PUSHDATA4")
+		}
+		size = int(binary.LittleEndian.Uint32(s))
+		s = s[4:]
+	}
+	if size > len(s) || size < 0 {
+		return 0, nil, errors.New("push extends beyond the end of the script")
+	}
+	*script = s[size:]
+	return op, s[:size], nil
+}
+
+// readPushes reads a script consisting only of pushes (which must push
+// something), as COptCCParams::COptCCParams(std::vector<unsigned char>) does,
+// including the small integer pushes OP_0 and OP_1 through OP_16.
+func readPushes(script []byte) ([][]byte, error) {
+	var pushes [][]byte
+	for len(script) > 0 {
+		op, data, err := readOp(&script)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case op == 0:
+			pushes = append(pushes, []byte{0})
+		case op >= op1 && op <= op16:
+			pushes = append(pushes, []byte{op - op1 + 1})
+		case op <= opPushData4 && len(data) > 0:
+			pushes = append(pushes, data)
+		default:
+			return nil, fmt.Errorf("unexpected opcode 0x%02x", op)
+		}
+	}
+	return pushes, nil
+}
+
+// ParseParams decodes a serialized COptCCParams.
+func ParseParams(data []byte) (*Params, error) {
+	pushes, err := readPushes(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad params")
+	}
+	if len(pushes) == 0 || len(pushes[0]) != 4 {
+		return nil, errors.New("params don't start with version, eval code, m and n")
+	}
+	p := &Params{
+		Version:  pushes[0][0],
+		EvalCode: EvalCode(pushes[0][1]),
+		M:        pushes[0][2],
+		N:        pushes[0][3],
+	}
+	if p.Version < VersionV1 || p.Version > VersionV3 {
+		return nil, fmt.Errorf("unknown params version %d", p.Version)
+	}
+	if p.M > p.N || (p.Version < VersionV3 && (p.N < 1 || p.N > 4)) {
+		return nil, fmt.Errorf("invalid %d of %d params", p.M, p.N)
+	}
+	if len(pushes) < 1+int(p.N) {
+		return nil, fmt.Errorf("params have fewer than %d destinations", p.N)
+	}
+	for _, d := range pushes[1 : 1+p.N] {
+		dest, err := parseDestination(p.Version, d)
+		if err != nil {
+			return nil, err
+		}
+		p.Destinations = append(p.Destinations, dest)
+	}
+	p.Data = pushes[1+p.N:]
+	return p, nil
+}
+
+func parseDestination(version uint8, d []byte) (Destination, error) {
+	switch {
+	case len(d) == 20:
+		return Destination{Type: DestPubKeyHash, Bytes: d}, nil
+	case len(d) == 33:
+		return Destination{Type: DestPubKey, Bytes: d}, nil
+	case version >= VersionV3 && len(d) == 21:
+		// Other than keys and key IDs, V3 destinations start with their type.
+		t := DestinationType(d[0])
+		if t == DestScriptHash || t == DestIdentity || t == DestIndex || t == DestQuantum {
+			return Destination{Type: t, Bytes: d[1:]}, nil
+		}
+	}
+	return Destination{}, fmt.Errorf("invalid destination %x", d)
+}
+
+// IsCryptoCondition reports whether the given output script is a
+// cryptocondition (that is, whether its second opcode is
+// OP_CHECKCRYPTOCONDITION), without decoding it.
+func IsCryptoCondition(script []byte) bool {
+	op, _, err := readOp(&script)
+	if err != nil || op == 0 || op >= opPushData1 {
+		return false
+	}
+	op, _, err = readOp(&script)
+	return err == nil && op == opCheckCryptoCondition
+}
+
+// Parse decodes a CC output script.
+func Parse(script []byte) (*Script, error) {
+	if !IsCryptoCondition(script) {
+		return nil, errors.New("not a cryptocondition script")
+	}
+	_, master, _ := readOp(&script)
+	readOp(&script) // OP_CHECKCRYPTOCONDITION
+
+	// The params are pushed, then dropped (leaving the condition's result),
+	// as CScript::GetBalancedData() requires.
+	var pushes [][]byte
+	stack := 0
+	for len(script) > 0 {
+		op, data, err := readOp(&script)
+		if err != nil {
+			return nil, err
+		}
+		if op == opDrop {
+			if stack--; stack < 0 {
+				return nil, errors.New("params drop more than they push")
+			}
+			continue
+		}
+		if op > opPushData4 || len(data) == 0 {
+			return nil, fmt.Errorf("unexpected opcode 0x%02x after the condition", op)
+		}
+		stack++
+		pushes = append(pushes, data)
+	}
+	if stack != 0 {
+		return nil, errors.New("params push more than they drop")
+	}
+
+	s := &Script{}
+	var err error
+	if s.Master, err = ParseParams(master); err != nil {
+		return nil, errors.Wrap(err, "master condition")
+	}
+	if len(pushes) == 0 {
+		return nil, errors.New("no condition params")
+	}
+	if s.Params, err = ParseParams(pushes[0]); err != nil {
+		return nil, err
+	}
+	// Any further pushes are more of the condition's data.
+	s.Params.Data = append(s.Params.Data, pushes[1:]...)
+	return s, nil
+}
+
+// Conditions returns the params of each of the script's conditions: the first
+// condition's, followed by those carried at the end of its data.
+func (s *Script) Conditions() []*Params {
+	data := s.Params.Data
+	var later []*Params
+	for i := len(data) - 1; i >= 0; i-- {
+		p, err := ParseParams(data[i])
+		if err != nil || p.Version != VersionV3 {
+			break
+		}
+		later = append([]*Params{p}, later...)
+	}
+	return append([]*Params{s.Params}, later...)
+}
+
+// Destinations returns the destinations of all of the script's conditions.
+func (s *Script) Destinations() []Destination {
+	var dests []Destination
+	for _, p := range s.Conditions() {
+		dests = append(dests, p.Destinations...)
+	}
+	return dests
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package cc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// Destinations used by the test vectors
+const (
+	identityA = "b26820ee0c9b1276aac834cf457026a575dfce84"
+	identityB = "51b1ff3d5fb1a7f3d4c7e0de54e2a4f5e11f5bdf"
+	keyID     = "5a4a4bd4a3fed4dd98a1c1adb7ff5e7ad4a05b0e"
+	pubKey    = "029f3c1a5e6b2d8c4f7a0e1b3d5c7f9a2b4d6e8f0a1c3e5b7d9f2a4c6e8b0d1f3a"
+)
+
+// The test vectors follow the layout of verusd's MakeMofNCCScript, spelled out
+// push by push.
+type ccTestVector struct {
+	name       string
+	script     string
+	eval       EvalCode
+	m, n       uint8
+	dests      []Destination
+	data       int        // number of data pushes in the first condition
+	conditions []EvalCode // eval codes of all the conditions
+}
+
+func dest(t DestinationType, h string) Destination {
+	b, _ := hex.DecodeString(h)
+	return Destination{Type: t, Bytes: b}
+}
+
+var ccTestVectors = []ccTestVector{
+	{
+		// Sending to an identity (i-address)
+		name: "pay to identity",
+		script: "1b" + "0403000101" + "1504" + identityA + // master, 1 of 1
+			"cc" + // OP_CHECKCRYPTOCONDITION
+			"1b" + "0403000101" + "1504" + identityA + // params
+			"75", // OP_DROP
+		eval:       EvalNone,
+		m:          1,
+		n:          1,
+		dests:      []Destination{dest(DestIdentity, identityA)},
+		conditions: []EvalCode{EvalNone},
+	},
+	{
+		// A reserve transfer (the object is in a PUSHDATA1)
+		name: "reserve transfer",
+		script: "27" + "0403000101" + "21" + pubKey +
+			"cc" +
+			"4c79" + "0403080101" + "21" + pubKey + "4c50" + strings.Repeat("cd", 80) +
+			"75",
+		eval:       EvalReserveTransfer,
+		m:          1,
+		n:          1,
+		dests:      []Destination{dest(DestPubKey, pubKey)},
+		data:       1,
+		conditions: []EvalCode{EvalReserveTransfer},
+	},
+	{
+		// An identity: the primary condition, carrying the identity (in a
+		// PUSHDATA2) and the revoke and recover conditions.
+		name: "identity",
+		script: "47" + "0403000103" + "1504" + identityA + "1504" + identityB + "1504" + identityB +
+			"cc" +
+			"4d1d01" + "04030e0101" + "1504" + identityA + "4cc8" + strings.Repeat("ab", 200) +
+			"1b" + "04030f0101" + "1504" + identityB +
+			"1b" + "0403100101" + "1504" + identityB +
+			"75",
+		eval:       EvalIdentityPrimary,
+		m:          1,
+		n:          1,
+		dests:      []Destination{dest(DestIdentity, identityA)},
+		data:       3,
+		conditions: []EvalCode{EvalIdentityPrimary, EvalIdentityRevoke, EvalIdentityRecover},
+	},
+	{
+		// 1 of 2, a key ID (R-address) and a public key
+		name: "stake guard",
+		script: "1a" + "0403000101" + "14" + keyID +
+			"cc" +
+			"3c" + "0403010102" + "14" + keyID + "21" + pubKey +
+			"75",
+		eval:       EvalStakeGuard,
+		m:          1,
+		n:          2,
+		dests:      []Destination{dest(DestPubKeyHash, keyID), dest(DestPubKey, pubKey)},
+		conditions: []EvalCode{EvalStakeGuard},
+	},
+	{
+		// The params may be pushed and dropped in pieces.
+		name: "params in two pushes",
+		script: "1b" + "0403000101" + "1504" + identityA +
+			"cc" +
+			"1b" + "0403000101" + "1504" + identityA + "75" +
+			"03" + "010203" + "75",
+		eval:       EvalNone,
+		m:          1,
+		n:          1,
+		dests:      []Destination{dest(DestIdentity, identityA)},
+		data:       1,
+		conditions: []EvalCode{EvalNone},
+	},
+}
+
+func TestParse(t *testing.T) {
+	for _, tv := range ccTestVectors {
+		script, _ := hex.DecodeString(tv.script)
+		if !IsCryptoCondition(script) {
+			t.Fatal(tv.name, ": not a cryptocondition")
+		}
+		s, err := Parse(script)
+		if err != nil {
+			t.Fatal(tv.name, ": ", err)
+		}
+		if s.Master.Version != VersionV3 || s.Master.EvalCode != EvalNone {
+			t.Fatal(tv.name, ": unexpected master condition")
+		}
+		p := s.Params
+		if p.Version != VersionV3 || p.EvalCode != tv.eval || p.M != tv.m || p.N != tv.n {
+			t.Fatalf("%s: unexpected params %v %d %d of %d", tv.name, p.EvalCode, p.Version, p.M, p.N)
+		}
+		if len(p.Destinations) != len(tv.dests) {
+			t.Fatal(tv.name, ": unexpected number of destinations ", len(p.Destinations))
+		}
+		for i, d := range p.Destinations {
+			if d.Type != tv.dests[i].Type || !bytes.Equal(d.Bytes, tv.dests[i].Bytes) {
+				t.Fatalf("%s: unexpected destination %d %x", tv.name, d.Type, d.Bytes)
+			}
+		}
+		if len(p.Data) != tv.data {
+			t.Fatal(tv.name, ": unexpected number of data pushes ", len(p.Data))
+		}
+		conditions := s.Conditions()
+		if len(conditions) != len(tv.conditions) {
+			t.Fatal(tv.name, ": unexpected number of conditions ", len(conditions))
+		}
+		for i, c := range conditions {
+			if c.EvalCode != tv.conditions[i] {
+				t.Fatal(tv.name, ": unexpected condition ", c.EvalCode)
+			}
+		}
+	}
+
+	// The identity's revoke and recover conditions have identity B.
+	script, _ := hex.DecodeString(ccTestVectors[2].script)
+	s, _ := Parse(script)
+	dests := s.Destinations()
+	if len(dests) != 3 || !bytes.Equal(dests[2].Bytes, dest(DestIdentity, identityB).Bytes) {
+		t.Fatal("unexpected identity destinations")
+	}
+}
+
+func TestParseNotCC(t *testing.T) {
+	for _, script := range []string{
+		// P2PKH
+		"76a914" + keyID + "88ac",
+		// P2SH
+		"a914" + keyID + "87",
+		// P2PK
+		"21" + pubKey + "ac",
+		"",
+		"cc",
+		// OP_0 as the condition
+		"00cc",
+	} {
+		b, _ := hex.DecodeString(script)
+		if IsCryptoCondition(b) {
+			t.Fatal("unexpected cryptocondition ", script)
+		}
+		if _, err := Parse(b); err == nil {
+			t.Fatal("Parse should have failed ", script)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	master := "1b" + "0403000101" + "1504" + identityA + "cc"
+	for _, tc := range []struct {
+		name   string
+		script string
+	}{
+		{"no params", master},
+		{"missing drop", master + "1b" + "0403000101" + "1504" + identityA},
+		{"extra drop", master + "1b" + "0403000101" + "1504" + identityA + "7575"},
+		{"truncated push", master + "1b" + "0403000101" + "1504" + identityA[:20]},
+		{"truncated PUSHDATA2", master + "4d01"},
+		{"not a push", master + "ac75"},
+		{"version 4", master + "1b" + "0404000101" + "1504" + identityA + "75"},
+		{"m > n", master + "1b" + "0403000201" + "1504" + identityA + "75"},
+		{"too few destinations", master + "1b" + "0403000102" + "1504" + identityA + "75"},
+		{"bad destination type", master + "1b" + "0403000101" + "1509" + identityA + "75"},
+		{"bad destination size", master + "0a" + "0403000101" + "04" + "01020304" + "75"},
+		{"bad master", "05" + "0404000101" + "cc" + "1b" + "0403000101" + "1504" + identityA + "75"},
+	} {
+		b, _ := hex.DecodeString(tc.script)
+		if _, err := Parse(b); err == nil {
+			t.Fatal(tc.name, ": Parse should have failed")
+		}
+	}
+}
+
+func TestEvalCodeString(t *testing.T) {
+	if EvalIdentityPrimary.String() != "EVAL_IDENTITY_PRIMARY" {
+		t.Fatal("unexpected name ", EvalIdentityPrimary.String())
+	}
+	if EvalCode(0xe2).String() != "EVAL_0xe2" {
+		t.Fatal("unexpected name ", EvalCode(0xe2).String())
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package cc
+
+import (
+	"github.com/asherda/lightwalletd/parser/internal/bytestring"
+	"github.com/pkg/errors"
+)
+
+// Reserve transfer flags (CReserveTransfer::EFlags)
+const (
+	TransferValid            = 0x1
+	TransferConvert          = 0x2
+	TransferPreConvert       = 0x4
+	TransferFeeOutput        = 0x8
+	TransferDoubleSend       = 0x10
+	TransferMintCurrency     = 0x20
+	TransferCrossSystem      = 0x40
+	TransferBurnChangePrice  = 0x80
+	TransferBurnChangeWeight = 0x100
+	TransferImportToSource   = 0x200
+	TransferReserveToReserve = 0x400
+	TransferRefund           = 0x800
+	TransferIdentityExport   = 0x1000
+	TransferCurrencyExport   = 0x2000
+	TransferArbitrageOnly    = 0x4000
+)
+
+// Transfer destination types (CTransferDestination), in the low bits of the
+// type byte; the high bits are flags.
+const (
+	TransferDestInvalid          = 0
+	TransferDestPubKey           = 1
+	TransferDestPubKeyHash       = 2
+	TransferDestScriptHash       = 3
+	TransferDestID               = 4
+	TransferDestFullID           = 5
+	TransferDestRegisterCurrency = 6
+	TransferDestQuantum          = 7
+	TransferDestNestedTransfer   = 8
+	TransferDestETH              = 9
+	TransferDestETHNFT           = 10
+	TransferDestRaw              = 11
+
+	TransferDestFlagAux     = 0x40 // followed by auxiliary destinations
+	TransferDestFlagGateway = 0x80 // followed by a gateway ID, code and fees
+)
+
+// The length of a currency ID (and other uint160s)
+const idLength = 20
+
+// CurrencyValue is an amount (in satoshis) of a currency.
+type CurrencyValue struct {
+	CurrencyID []byte // 20 bytes
+	Amount     int64
+}
+
+// TransferDestination is where a reserve transfer's output goes.
+type TransferDestination struct {
+	Type        uint8 // including the flags
+	Bytes       []byte
+	GatewayID   []byte // if TransferDestFlagGateway
+	GatewayCode []byte
+	Fees        int64
+	AuxDests    [][]byte // if TransferDestFlagAux
+}
+
+// DestType returns the destination's type, without the flags.
+func (d *TransferDestination) DestType() uint8 {
+	return d.Type &^ (TransferDestFlagAux | TransferDestFlagGateway)
+}
+
+// ReserveTransfer is a decoded CReserveTransfer, the data of an
+// EvalReserveTransfer output: a send, conversion or cross-chain transfer
+// of currencies, waiting to be included in an export.
+type ReserveTransfer struct {
+	Version         uint32
+	Values          []CurrencyValue // the currencies being transferred
+	Flags           uint32
+	FeeCurrencyID   []byte
+	Fees            int64
+	Destination     TransferDestination
+	DestCurrencyID  []byte
+	SecondReserveID []byte // if TransferReserveToReserve
+	DestSystemID    []byte // if TransferCrossSystem
+}
+
+// readVarInt reads a (Bitcoin serialize.h) VARINT: base-128, most
+// significant group first, with each continuation adding one.
+func readVarInt(s *bytestring.String, out *uint64) bool {
+	var n uint64
+	for i := 0; i < 10; i++ {
+		var b byte
+		if !s.ReadByte(&b) {
+			return false
+		}
+		n = n<<7 | uint64(b&0x7f)
+		if b&0x80 == 0 {
+			*out = n
+			return true
+		}
+		n++
+	}
+	return false
+}
+
+// readID reads a uint160 (such as a currency ID).
+func readID(s *bytestring.String, out *[]byte) bool {
+	return s.ReadBytes(out, idLength)
+}
+
+func readTransferDestination(s *bytestring.String, d *TransferDestination) error {
+	if !s.ReadByte(&d.Type) {
+		return errors.New("could not read destination type")
+	}
+	var dest bytestring.String
+	if !s.ReadCompactLengthPrefixed(&dest) {
+		return errors.New("could not read destination")
+	}
+	d.Bytes = []byte(dest)
+	if d.Type&TransferDestFlagGateway != 0 {
+		if !readID(s, &d.GatewayID) || !readID(s, &d.GatewayCode) || !s.ReadInt64(&d.Fees) {
+			return errors.New("could not read destination gateway")
+		}
+	}
+	if d.Type&TransferDestFlagAux != 0 {
+		var count int
+		if !s.ReadCompactSize(&count) {
+			return errors.New("could not read auxiliary destination count")
+		}
+		for i := 0; i < count; i++ {
+			var aux bytestring.String
+			if !s.ReadCompactLengthPrefixed(&aux) {
+				return errors.New("could not read auxiliary destination")
+			}
+			d.AuxDests = append(d.AuxDests, []byte(aux))
+		}
+	}
+	return nil
+}
+
+// ParseReserveTransfer decodes a serialized CReserveTransfer (as in the data
+// of an EvalReserveTransfer condition).
+func ParseReserveTransfer(data []byte) (*ReserveTransfer, error) {
+	s := bytestring.String(data)
+	rt := &ReserveTransfer{}
+
+	// The CTokenOutput: version, then the currency values (a vector of
+	// currency ID and amount pairs).
+	var v uint64
+	if !readVarInt(&s, &v) {
+		return nil, errors.New("could not read version")
+	}
+	rt.Version = uint32(v)
+	var count int
+	if !s.ReadCompactSize(&count) {
+		return nil, errors.New("could not read currency value count")
+	}
+	for i := 0; i < count; i++ {
+		var cv CurrencyValue
+		if !readID(&s, &cv.CurrencyID) || !s.ReadInt64(&cv.Amount) {
+			return nil, errors.New("could not read currency value")
+		}
+		rt.Values = append(rt.Values, cv)
+	}
+
+	if !readVarInt(&s, &v) {
+		return nil, errors.New("could not read flags")
+	}
+	rt.Flags = uint32(v)
+	if !readID(&s, &rt.FeeCurrencyID) {
+		return nil, errors.New("could not read fee currency")
+	}
+	if !readVarInt(&s, &v) {
+		return nil, errors.New("could not read fees")
+	}
+	rt.Fees = int64(v)
+	if err := readTransferDestination(&s, &rt.Destination); err != nil {
+		return nil, err
+	}
+	if !readID(&s, &rt.DestCurrencyID) {
+		return nil, errors.New("could not read destination currency")
+	}
+	if rt.Flags&TransferReserveToReserve != 0 && !readID(&s, &rt.SecondReserveID) {
+		return nil, errors.New("could not read second reserve currency")
+	}
+	if rt.Flags&TransferCrossSystem != 0 && !readID(&s, &rt.DestSystemID) {
+		return nil, errors.New("could not read destination system")
+	}
+	if !s.Empty() {
+		return nil, errors.New("unexpected data after reserve transfer")
+	}
+	return rt, nil
+}
+
+// ReserveTransfer decodes the reserve transfer carried by the script, or
+// returns nil (and no error) if it isn't a reserve transfer output.
+func (s *Script) ReserveTransfer() (*ReserveTransfer, error) {
+	if s.Params.EvalCode != EvalReserveTransfer {
+		return nil, nil
+	}
+	if len(s.Params.Data) == 0 {
+		return nil, errors.New("reserve transfer has no data")
+	}
+	return ParseReserveTransfer(s.Params.Data[0])
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package cc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/asherda/lightwalletd/parser/internal/bytestring"
+)
+
+// Currency IDs used by the test vectors
+const (
+	currencyA = "a6ef9ea235635e328124ff3429db9f9e91b64e2d"
+	currencyB = "c1d4a3a2c1e0f7b8d9a6e5f4c3b2a19080706050"
+)
+
+func TestReadVarInt(t *testing.T) {
+	for _, tc := range []struct {
+		hex   string
+		value uint64
+	}{
+		{"00", 0},
+		{"7f", 127},
+		{"8000", 128},
+		{"8100", 256},
+		{"809b20", 20000},
+		{"fe7f", 16383},
+		{"ff00", 16384},
+	} {
+		b, _ := hex.DecodeString(tc.hex)
+		s := bytestring.String(b)
+		var v uint64
+		if !readVarInt(&s, &v) || v != tc.value || !s.Empty() {
+			t.Fatal("unexpected VARINT ", tc.hex, v)
+		}
+	}
+	for _, h := range []string{"", "80", "ffffffffffffffffffff7f"} {
+		b, _ := hex.DecodeString(h)
+		s := bytestring.String(b)
+		var v uint64
+		if readVarInt(&s, &v) {
+			t.Fatal("readVarInt should have failed ", h)
+		}
+	}
+}
+
+// The vectors follow the layout of verusd's CReserveTransfer serialization.
+var (
+	// 1 VRSC (currency A) converted to currency B, sent to an R-address,
+	// paying 0.0002 (20000 satoshis) in fees.
+	simpleTransfer = "01" + "01" + currencyA + "00e1f50500000000" + // values
+		"03" + currencyA + "809b20" + // flags, fee currency, fees
+		"02" + "14" + keyID + // destination
+		currencyB
+	// A reserve to reserve conversion to an identity on another system,
+	// with a gateway and an auxiliary destination.
+	crossSystemTransfer = "01" + "02" + currencyA + "0100000000000000" + currencyB + "0200000000000000" +
+		"8743" + currencyB + "00" + // flags 0x443 (ReserveToReserve|CrossSystem|Convert|Valid)
+		"c4" + "14" + identityA + identityB + keyID + "1027000000000000" +
+		"01" + "14" + identityB +
+		currencyA + currencyB + identityB
+)
+
+func TestParseReserveTransfer(t *testing.T) {
+	data, _ := hex.DecodeString(simpleTransfer)
+	rt, err := ParseReserveTransfer(data)
+	if err != nil {
+		t.Fatal("ParseReserveTransfer failed:", err)
+	}
+	if rt.Version != 1 || len(rt.Values) != 1 || hex.EncodeToString(rt.Values[0].CurrencyID) != currencyA ||
+		rt.Values[0].Amount != 100000000 {
+		t.Fatal("unexpected values ", rt.Version, rt.Values)
+	}
+	if rt.Flags != TransferValid|TransferConvert || hex.EncodeToString(rt.FeeCurrencyID) != currencyA ||
+		rt.Fees != 20000 {
+		t.Fatal("unexpected flags or fees ", rt.Flags, rt.Fees)
+	}
+	if rt.Destination.DestType() != TransferDestPubKeyHash || hex.EncodeToString(rt.Destination.Bytes) != keyID {
+		t.Fatal("unexpected destination ", rt.Destination)
+	}
+	if hex.EncodeToString(rt.DestCurrencyID) != currencyB || rt.SecondReserveID != nil || rt.DestSystemID != nil {
+		t.Fatal("unexpected currencies ", rt.DestCurrencyID, rt.SecondReserveID, rt.DestSystemID)
+	}
+
+	data, _ = hex.DecodeString(crossSystemTransfer)
+	rt, err = ParseReserveTransfer(data)
+	if err != nil {
+		t.Fatal("ParseReserveTransfer failed:", err)
+	}
+	if len(rt.Values) != 2 || rt.Values[1].Amount != 2 || rt.Fees != 0 ||
+		rt.Flags != TransferValid|TransferConvert|TransferCrossSystem|TransferReserveToReserve {
+		t.Fatalf("unexpected transfer %+v", rt)
+	}
+	d := rt.Destination
+	if d.DestType() != TransferDestID || hex.EncodeToString(d.Bytes) != identityA ||
+		hex.EncodeToString(d.GatewayID) != identityB || hex.EncodeToString(d.GatewayCode) != keyID ||
+		d.Fees != 10000 || len(d.AuxDests) != 1 || hex.EncodeToString(d.AuxDests[0]) != identityB {
+		t.Fatalf("unexpected destination %+v", d)
+	}
+	if hex.EncodeToString(rt.SecondReserveID) != currencyB || hex.EncodeToString(rt.DestSystemID) != identityB {
+		t.Fatal("unexpected currencies ", rt.SecondReserveID, rt.DestSystemID)
+	}
+
+	// Truncated anywhere, or with trailing data, it's invalid.
+	for _, vector := range []string{simpleTransfer, crossSystemTransfer} {
+		data, _ := hex.DecodeString(vector)
+		for i := 0; i < len(data); i++ {
+			if _, err := ParseReserveTransfer(data[:i]); err == nil {
+				t.Fatal("ParseReserveTransfer should have failed, truncated at ", i)
+			}
+		}
+		if _, err := ParseReserveTransfer(append(data, 0)); err == nil {
+			t.Fatal("ParseReserveTransfer should have failed, trailing data")
+		}
+	}
+}
+
+func TestScriptReserveTransfer(t *testing.T) {
+	// The reserve transfer in a CC output script (in PUSHDATA1s)
+	data, _ := hex.DecodeString(simpleTransfer)
+	params := "0403080101" + "21" + pubKey + "4c" + hex.EncodeToString([]byte{byte(len(data))}) + simpleTransfer
+	script, _ := hex.DecodeString("27" + "0403000101" + "21" + pubKey + "cc" +
+		"4c" + hex.EncodeToString([]byte{byte(len(params) / 2)}) + params + "75")
+	s, err := Parse(script)
+	if err != nil {
+		t.Fatal("Parse failed:", err)
+	}
+	rt, err := s.ReserveTransfer()
+	if err != nil || rt == nil || !bytes.Equal(rt.Destination.Bytes, dest(DestPubKeyHash, keyID).Bytes) {
+		t.Fatal("unexpected reserve transfer ", rt, err)
+	}
+
+	// Other outputs aren't reserve transfers.
+	script, _ = hex.DecodeString(ccTestVectors[0].script)
+	s, _ = Parse(script)
+	if rt, err := s.ReserveTransfer(); rt != nil || err != nil {
+		t.Fatal("unexpected reserve transfer ", rt, err)
+	}
+	// But a reserve transfer output must carry a valid one.
+	script, _ = hex.DecodeString(ccTestVectors[1].script)
+	s, _ = Parse(script)
+	if _, err := s.ReserveTransfer(); err == nil {
+		t.Fatal("ReserveTransfer should have failed")
+	}
+}
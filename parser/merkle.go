@@ -0,0 +1,118 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/pkg/errors"
+)
+
+// hashPair returns SHA256d of the concatenation of the two hashes.
+func hashPair(left, right []byte) []byte {
+	pair := make([]byte, 0, 64)
+	pair = append(pair, left...)
+	pair = append(pair, right...)
+	digest := sha256.Sum256(pair)
+	digest = sha256.Sum256(digest[:])
+	return digest[:]
+}
+
+// merkleLevel returns the level of the merkle tree above the given one; a
+// level with an odd number of entries has its last entry paired with itself.
+func merkleLevel(level [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		right := level[i]
+		if i+1 < len(level) {
+			right = level[i+1]
+		}
+		next = append(next, hashPair(level[i], right))
+	}
+	return next
+}
+
+// merkleRoot computes the root of the Bitcoin-style merkle tree over the
+// given (little-endian) hashes. It also reports whether the tree is mutated:
+// whether a level pairs two identical entries (other than an odd last entry
+// with itself), in which case a different list of hashes, with some of them
+// repeated, has the same root (CVE-2012-2459).
+func merkleRoot(hashes [][]byte) (root []byte, mutated bool) {
+	if len(hashes) == 0 {
+		return make([]byte, 32), false
+	}
+	level := hashes
+	for len(level) > 1 {
+		for i := 0; i+1 < len(level); i += 2 {
+			if bytes.Equal(level[i], level[i+1]) {
+				mutated = true
+			}
+		}
+		level = merkleLevel(level)
+	}
+	return level[0], mutated
+}
+
+// merkleBranch returns the siblings of the path from the hash at the given
+// index up to (not including) the root.
+func merkleBranch(hashes [][]byte, index int) [][]byte {
+	branch := [][]byte{}
+	level := hashes
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling >= len(level) {
+			sibling = index
+		}
+		branch = append(branch, level[sibling])
+		level = merkleLevel(level)
+		index /= 2
+	}
+	return branch
+}
+
+// merkleBranchRoot returns the root implied by the branch from the hash at
+// the given index.
+func merkleBranchRoot(hash []byte, index uint32, branch [][]byte) []byte {
+	for _, sibling := range branch {
+		if index&1 == 0 {
+			hash = hashPair(hash, sibling)
+		} else {
+			hash = hashPair(sibling, hash)
+		}
+		index >>= 1
+	}
+	return hash
+}
+
+// VerifyTransactionProof checks that the proof's merkle branch links its
+// txid, at its index, to the merkle root in its block header. (Whether the
+// header is in the best chain is up to the caller.)
+func VerifyTransactionProof(proof *walletrpc.TransactionProof) error {
+	hdr := NewBlockHeader()
+	rest, err := hdr.ParseFromSlice(proof.Header)
+	if err != nil {
+		return errors.Wrap(err, "parsing block header")
+	}
+	if len(rest) != 0 {
+		return errors.New("block header is too long")
+	}
+	if len(proof.Txid) != 32 {
+		return errors.New("txid has invalid length")
+	}
+	if len(proof.Branch) < 32 && proof.Index>>uint(len(proof.Branch)) != 0 {
+		return errors.New("index is out of range of the branch")
+	}
+	for _, hash := range proof.Branch {
+		if len(hash) != 32 {
+			return errors.New("branch hash has invalid length")
+		}
+	}
+	if !bytes.Equal(merkleBranchRoot(proof.Txid, proof.Index, proof.Branch), hdr.HashMerkleRoot) {
+		return errors.New("merkle branch doesn't lead to the block's merkle root")
+	}
+	return nil
+}
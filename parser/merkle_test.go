@@ -0,0 +1,81 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package parser
+
+import (
+	"bufio"
+	"encoding/hex"
+	"os"
+	"testing"
+)
+
+func TestTransactionProof(t *testing.T) {
+	testBlocks, err := os.Open("../testdata/blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testBlocks.Close()
+
+	var blockData [][]byte
+	var blocks []*Block
+	var txs []*Transaction
+	scan := bufio.NewScanner(testBlocks)
+	for scan.Scan() {
+		data, _ := hex.DecodeString(scan.Text())
+		block := NewBlock()
+		if _, err := block.ParseFromSlice(data); err != nil {
+			t.Fatal(err)
+		}
+		blockData = append(blockData, data)
+		blocks = append(blocks, block)
+		txs = append(txs, block.Transactions()...)
+	}
+	// Also blocks of 2 to 6 transactions, for odd-length merkle tree levels.
+	for n := 1; n < len(txs); n++ {
+		block := NewBlock()
+		block.ParseFromSlice(blockData[0])
+		block.AppendTransactions(txs[1 : n+1]...)
+		blocks = append(blocks, block)
+	}
+
+	for i, block := range blocks {
+		for index := 0; index < block.GetTxCount(); index++ {
+			proof, err := block.TransactionProof(index)
+			if err != nil {
+				t.Fatal("TransactionProof failed:", err)
+			}
+			if proof.Height != uint64(block.GetHeight()) || proof.Index != uint32(index) {
+				t.Fatal("unexpected proof ", proof)
+			}
+			if err := VerifyTransactionProof(proof); err != nil {
+				t.Fatal("block ", i, " index ", index, ": VerifyTransactionProof failed: ", err)
+			}
+
+			// Changing the txid, the index or the branch invalidates the proof.
+			proof.Txid = txs[0].GetDisplayHash()
+			if VerifyTransactionProof(proof) == nil {
+				t.Fatal("VerifyTransactionProof should have failed, wrong txid")
+			}
+			proof.Txid = block.Transactions()[index].GetEncodableHash()
+			if len(proof.Branch) > 0 {
+				proof.Index ^= 1
+				if VerifyTransactionProof(proof) == nil && proof.Index < uint32(block.GetTxCount()) {
+					t.Fatal("VerifyTransactionProof should have failed, wrong index")
+				}
+				proof.Index ^= 1
+				proof.Branch[0] = proof.Txid
+				if VerifyTransactionProof(proof) == nil && index^1 < block.GetTxCount() {
+					t.Fatal("VerifyTransactionProof should have failed, wrong branch")
+				}
+			}
+			proof.Index = 1 << uint(len(proof.Branch))
+			if VerifyTransactionProof(proof) == nil {
+				t.Fatal("VerifyTransactionProof should have failed, index out of range")
+			}
+		}
+		if _, err := block.TransactionProof(block.GetTxCount()); err == nil {
+			t.Fatal("TransactionProof should have failed, index out of range")
+		}
+	}
+}
@@ -6,8 +6,12 @@
 package parser
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"encoding/binary"
+	"math"
 
+	"github.com/asherda/lightwalletd/parser/cc"
 	"github.com/asherda/lightwalletd/parser/internal/bytestring"
 	"github.com/asherda/lightwalletd/walletrpc"
 	"github.com/pkg/errors"
@@ -67,6 +71,24 @@ func (tx *txIn) ParseFromSlice(data []byte) ([]byte, error) {
 	return []byte(s), nil
 }
 
+// MarshalBinary returns the input in serialized form.
+func (tx *txIn) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 32+4+CompactLengthPrefixedLen(len(tx.ScriptSig))+4))
+	buf.Write(tx.PrevTxHash)
+	binary.Write(buf, binary.LittleEndian, tx.PrevTxOutIndex)
+	writeCompactLengthPrefixed(buf, tx.ScriptSig)
+	binary.Write(buf, binary.LittleEndian, tx.SequenceNumber)
+	return buf.Bytes(), nil
+}
+
+// ToCompact returns the outpoint the input spends.
+func (tx *txIn) ToCompact() *walletrpc.CompactTxIn {
+	return &walletrpc.CompactTxIn{
+		PrevoutTxid:  tx.PrevTxHash,
+		PrevoutIndex: tx.PrevTxOutIndex,
+	}
+}
+
 // Txout format as described in https://en.bitcoin.it/wiki/Transaction
 type txOut struct {
 	// Non-negative int giving the number of zatoshis to be transferred
@@ -90,6 +112,34 @@ func (tx *txOut) ParseFromSlice(data []byte) ([]byte, error) {
 	return []byte(s), nil
 }
 
+// MarshalBinary returns the output in serialized form.
+func (tx *txOut) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 8+CompactLengthPrefixedLen(len(tx.Script))))
+	binary.Write(buf, binary.LittleEndian, tx.Value)
+	writeCompactLengthPrefixed(buf, tx.Script)
+	return buf.Bytes(), nil
+}
+
+// ToCompact returns the output's value and the key or script hash its script
+// pays to, if it's a standard P2PKH or P2SH script, else the whole script.
+func (tx *txOut) ToCompact() *walletrpc.CompactTxOut {
+	out := &walletrpc.CompactTxOut{Value: tx.Value}
+	script := tx.Script
+	switch {
+	case len(script) == 25 && script[0] == 0x76 && script[1] == 0xa9 && script[2] == 20 &&
+		script[23] == 0x88 && script[24] == 0xac:
+		// OP_DUP OP_HASH160 <hash> OP_EQUALVERIFY OP_CHECKSIG
+		out.AddressHash = script[3:23]
+	case len(script) == 23 && script[0] == 0xa9 && script[1] == 20 && script[22] == 0x87:
+		// OP_HASH160 <hash> OP_EQUAL
+		out.AddressHash = script[2:22]
+		out.ScriptHash = true
+	default:
+		out.ScriptPubKey = script
+	}
+	return out
+}
+
 // spend is a Sapling Spend Description as described in 7.3 of the Zcash
 // protocol spec.  Total size is 384 bytes.
 type spend struct {
@@ -131,6 +181,18 @@ func (p *spend) ParseFromSlice(data []byte) ([]byte, error) {
 	return []byte(s), nil
 }
 
+// MarshalBinary returns the Spend Description in serialized form.
+func (p *spend) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 384))
+	buf.Write(p.cv)
+	buf.Write(p.anchor)
+	buf.Write(p.nullifier)
+	buf.Write(p.rk)
+	buf.Write(p.zkproof)
+	buf.Write(p.spendAuthSig)
+	return buf.Bytes(), nil
+}
+
 func (p *spend) ToCompact() *walletrpc.CompactSpend {
 	return &walletrpc.CompactSpend{
 		Nf: p.nullifier,
@@ -178,6 +240,18 @@ func (p *output) ParseFromSlice(data []byte) ([]byte, error) {
 	return []byte(s), nil
 }
 
+// MarshalBinary returns the Output Description in serialized form.
+func (p *output) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 948))
+	buf.Write(p.cv)
+	buf.Write(p.cmu)
+	buf.Write(p.ephemeralKey)
+	buf.Write(p.encCiphertext)
+	buf.Write(p.outCiphertext)
+	buf.Write(p.zkproof)
+	return buf.Bytes(), nil
+}
+
 func (p *output) ToCompact() *walletrpc.CompactOutput {
 	return &walletrpc.CompactOutput{
 		Cmu:        p.cmu,
@@ -268,6 +342,37 @@ func (p *joinSplit) ParseFromSlice(data []byte) ([]byte, error) {
 	return []byte(s), nil
 }
 
+// MarshalBinary returns the JoinSplit description in serialized form; the
+// proof is PHGR13 or Groth16 depending on the transaction version.
+func (p *joinSplit) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 1698))
+	binary.Write(buf, binary.LittleEndian, p.vpubOld)
+	binary.Write(buf, binary.LittleEndian, p.vpubNew)
+	buf.Write(p.anchor)
+	for i := 0; i < 2; i++ {
+		buf.Write(p.nullifiers[i])
+	}
+	for i := 0; i < 2; i++ {
+		buf.Write(p.commitments[i])
+	}
+	buf.Write(p.ephemeralKey)
+	buf.Write(p.randomSeed)
+	for i := 0; i < 2; i++ {
+		buf.Write(p.vmacs[i])
+	}
+	if p.version == 2 || p.version == 3 {
+		buf.Write(p.proofPHGR13)
+	} else if p.version >= 4 {
+		buf.Write(p.proofGroth16)
+	} else {
+		return nil, errors.New("unexpected transaction version")
+	}
+	for i := 0; i < 2; i++ {
+		buf.Write(p.encCiphertexts[i])
+	}
+	return buf.Bytes(), nil
+}
+
 // Transaction encodes a full (zcashd) transaction.
 type Transaction struct {
 	*rawTransaction
@@ -307,12 +412,128 @@ func (tx *Transaction) HasSaplingElements() bool {
 	return tx.version >= 4 && (len(tx.shieldedSpends)+len(tx.shieldedOutputs)) > 0
 }
 
-// ToCompact converts the given (full) transaction to compact format.
+// IsCoinbase indicates whether a transaction is a coinbase transaction,
+// that is, its only input spends the null outpoint.
+func (tx *Transaction) IsCoinbase() bool {
+	return len(tx.transparentInputs) == 1 &&
+		tx.transparentInputs[0].PrevTxOutIndex == 0xffffffff &&
+		bytes.Equal(tx.transparentInputs[0].PrevTxHash, make([]byte, 32))
+}
+
+// ExpiryHeight returns the height of the last block the transaction can be
+// mined in, or zero if it doesn't expire.
+func (tx *Transaction) ExpiryHeight() int {
+	return int(tx.nExpiryHeight)
+}
+
+// Spent returns what the transaction spends: the outpoints of its transparent
+// inputs (the txid, little-endian, then the index), and its Sprout and Sapling
+// nullifiers. Transactions that spend any of the same can't all be mined.
+func (tx *Transaction) Spent() [][]byte {
+	var spent [][]byte
+	if !tx.IsCoinbase() {
+		for _, in := range tx.transparentInputs {
+			outpoint := make([]byte, 36)
+			copy(outpoint, in.PrevTxHash)
+			binary.LittleEndian.PutUint32(outpoint[32:], in.PrevTxOutIndex)
+			spent = append(spent, outpoint)
+		}
+	}
+	for _, js := range tx.joinSplits {
+		spent = append(spent, js.nullifiers[0], js.nullifiers[1])
+	}
+	for _, spend := range tx.shieldedSpends {
+		spent = append(spent, spend.nullifier)
+	}
+	return spent
+}
+
+// CCOutput decodes the script of the transparent output at the given index as
+// a Verus cryptocondition (a smart transaction output). It returns nil, and no
+// error, if the output isn't a cryptocondition.
+func (tx *Transaction) CCOutput(index int) (*cc.Script, error) {
+	if index < 0 || index >= len(tx.transparentOutputs) {
+		return nil, errors.New("output index out of range")
+	}
+	script := tx.transparentOutputs[index].Script
+	if !cc.IsCryptoCondition(script) {
+		return nil, nil
+	}
+	return cc.Parse(script)
+}
+
+// CCOutputs returns the decoded cryptocondition of each of the transaction's
+// transparent outputs, by output index; the entry is nil for an output that
+// isn't a cryptocondition (or can't be decoded).
+func (tx *Transaction) CCOutputs() []*cc.Script {
+	scripts := make([]*cc.Script, len(tx.transparentOutputs))
+	for i := range tx.transparentOutputs {
+		scripts[i], _ = tx.CCOutput(i)
+	}
+	return scripts
+}
+
+// PrevoutValue returns the value of the given output (by txid, little-endian,
+// and index) of an earlier transaction, if it's known.
+type PrevoutValue func(txid []byte, index uint32) (uint64, bool)
+
+// Fee returns the transaction's fee: the value of its transparent inputs
+// (from prevoutValue, which may be nil) and of what it takes out of the
+// shielded pools, less the value of its transparent outputs and of what it
+// puts into the shielded pools. It returns false if the fee can't be
+// determined: for a coinbase, or if an input's value isn't known.
+func (tx *Transaction) Fee(prevoutValue PrevoutValue) (uint64, bool) {
+	if tx.IsCoinbase() {
+		return 0, false
+	}
+	var in, out uint64
+	for _, txin := range tx.transparentInputs {
+		if prevoutValue == nil {
+			return 0, false
+		}
+		value, ok := prevoutValue(txin.PrevTxHash, txin.PrevTxOutIndex)
+		if !ok {
+			return 0, false
+		}
+		in += value
+	}
+	for _, txout := range tx.transparentOutputs {
+		out += txout.Value
+	}
+	for _, js := range tx.joinSplits {
+		in += js.vpubNew
+		out += js.vpubOld
+	}
+	if tx.valueBalance >= 0 {
+		in += uint64(tx.valueBalance)
+	} else {
+		out += uint64(-tx.valueBalance)
+	}
+	if out > in {
+		// Not a valid transaction (or one that mints, such as a Verus import)
+		return 0, false
+	}
+	return in - out, true
+}
+
+// compactFee returns the fee as in CompactTx, zero if it's unknown (or too
+// large to fit).
+func (tx *Transaction) compactFee(prevoutValue PrevoutValue) uint32 {
+	fee, ok := tx.Fee(prevoutValue)
+	if !ok || fee > math.MaxUint32 {
+		return 0
+	}
+	return uint32(fee)
+}
+
+// ToCompact converts the given (full) transaction to compact format. The
+// fee is known only if the transaction has no transparent inputs; see
+// Block.ToCompactWithFees.
 func (tx *Transaction) ToCompact(index int) *walletrpc.CompactTx {
 	ctx := &walletrpc.CompactTx{
-		Index: uint64(index), // index is contextual
-		Hash:  tx.GetEncodableHash(),
-		//Fee:     0, // TODO: calculate fees
+		Index:   uint64(index), // index is contextual
+		Hash:    tx.GetEncodableHash(),
+		Fee:     tx.compactFee(nil),
 		Spends:  make([]*walletrpc.CompactSpend, len(tx.shieldedSpends)),
 		Outputs: make([]*walletrpc.CompactOutput, len(tx.shieldedOutputs)),
 	}
@@ -322,6 +543,14 @@ func (tx *Transaction) ToCompact(index int) *walletrpc.CompactTx {
 	for i, output := range tx.shieldedOutputs {
 		ctx.Outputs[i] = output.ToCompact()
 	}
+	if !tx.IsCoinbase() {
+		for _, in := range tx.transparentInputs {
+			ctx.Vin = append(ctx.Vin, in.ToCompact())
+		}
+	}
+	for _, out := range tx.transparentOutputs {
+		ctx.Vout = append(ctx.Vout, out.ToCompact())
+	}
 	return ctx
 }
 
@@ -467,13 +696,88 @@ func (tx *Transaction) ParseFromSlice(data []byte) ([]byte, error) {
 		}
 	}
 
-	// TODO: implement rawBytes with MarshalBinary() instead
 	txLen := len(data) - len(s)
 	tx.rawBytes = data[:txLen]
 
 	return []byte(s), nil
 }
 
+// MarshalBinary returns the transaction in serialized form, as it was (or
+// would be) parsed by ParseFromSlice.
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	header := tx.version
+	if tx.fOverwintered {
+		header |= 1 << 31
+	}
+	binary.Write(buf, binary.LittleEndian, header)
+	if tx.version >= 3 {
+		binary.Write(buf, binary.LittleEndian, tx.nVersionGroupID)
+	}
+
+	WriteCompactLengthPrefixedLen(buf, len(tx.transparentInputs))
+	for _, ti := range tx.transparentInputs {
+		b, err := ti.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "while serializing transparent input")
+		}
+		buf.Write(b)
+	}
+	WriteCompactLengthPrefixedLen(buf, len(tx.transparentOutputs))
+	for _, to := range tx.transparentOutputs {
+		b, err := to.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "while serializing transparent output")
+		}
+		buf.Write(b)
+	}
+
+	binary.Write(buf, binary.LittleEndian, tx.nLockTime)
+	if tx.fOverwintered {
+		binary.Write(buf, binary.LittleEndian, tx.nExpiryHeight)
+	}
+
+	if tx.version >= 4 {
+		binary.Write(buf, binary.LittleEndian, tx.valueBalance)
+		WriteCompactLengthPrefixedLen(buf, len(tx.shieldedSpends))
+		for _, sp := range tx.shieldedSpends {
+			b, err := sp.MarshalBinary()
+			if err != nil {
+				return nil, errors.Wrap(err, "while serializing shielded Spend")
+			}
+			buf.Write(b)
+		}
+		WriteCompactLengthPrefixedLen(buf, len(tx.shieldedOutputs))
+		for _, out := range tx.shieldedOutputs {
+			b, err := out.MarshalBinary()
+			if err != nil {
+				return nil, errors.Wrap(err, "while serializing shielded Output")
+			}
+			buf.Write(b)
+		}
+	}
+
+	if tx.version >= 2 {
+		WriteCompactLengthPrefixedLen(buf, len(tx.joinSplits))
+		if len(tx.joinSplits) > 0 {
+			for _, js := range tx.joinSplits {
+				b, err := js.MarshalBinary()
+				if err != nil {
+					return nil, errors.Wrap(err, "while serializing JoinSplit")
+				}
+				buf.Write(b)
+			}
+			buf.Write(tx.joinSplitPubKey)
+			buf.Write(tx.joinSplitSig)
+		}
+	}
+
+	if tx.version >= 4 && len(tx.shieldedSpends)+len(tx.shieldedOutputs) > 0 {
+		buf.Write(tx.bindingSig)
+	}
+	return buf.Bytes(), nil
+}
+
 // NewTransaction is the constructor for a full transaction.
 func NewTransaction() *Transaction {
 	return &Transaction{
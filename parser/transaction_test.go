@@ -12,6 +12,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/asherda/lightwalletd/parser/cc"
 	"github.com/asherda/lightwalletd/parser/internal/bytestring"
 )
 
@@ -194,6 +195,12 @@ func TestSproutTransactionParser(t *testing.T) {
 			continue
 		}
 
+		// Serializing the parsed transaction reproduces it exactly
+		if txBytes, err := tx.MarshalBinary(); err != nil || !bytes.Equal(txBytes, rawTxData[i]) {
+			t.Errorf("Test %d: MarshalBinary did not round-trip: %v", i, err)
+			continue
+		}
+
 		// Transaction metadata
 		if !subTestCommonBlockMeta(&tt, tx, t, i) {
 			continue
@@ -262,6 +269,10 @@ func subTestCommonBlockMeta(tt *txTestVector, tx *Transaction, t *testing.T, cas
 		t.Errorf("Test %d: unexpected nExpiryHeight", caseNum)
 		return false
 	}
+	if tx.ExpiryHeight() != int(expiryHeight) {
+		t.Errorf("Test %d: unexpected ExpiryHeight", caseNum)
+		return false
+	}
 
 	return true
 }
@@ -702,6 +713,12 @@ func TestSaplingTransactionParser(t *testing.T) {
 			continue
 		}
 
+		// Serializing the parsed transaction reproduces it exactly
+		if txBytes, err := tx.MarshalBinary(); err != nil || !bytes.Equal(txBytes, rawTxData[i]) {
+			t.Errorf("Test %d: MarshalBinary did not round-trip: %v", i, err)
+			continue
+		}
+
 		// If the transaction is shorter than it should be, parsing
 		// should fail gracefully
 		for j := 0; j < len(rawTxData[i]); j++ {
@@ -762,6 +779,21 @@ func TestSaplingTransactionParser(t *testing.T) {
 			continue
 		}
 
+		spent := tx.Spent()
+		if len(spent) != len(tx.transparentInputs)+2*len(tx.joinSplits)+len(tx.shieldedSpends) {
+			t.Errorf("Test %d: unexpected Spent count %d", i, len(spent))
+			continue
+		}
+		if len(tx.shieldedSpends) > 0 && !bytes.Equal(spent[len(spent)-1], tx.shieldedSpends[len(tx.shieldedSpends)-1].nullifier) {
+			t.Errorf("Test %d: unexpected Spent nullifier", i)
+			continue
+		}
+		if len(tx.transparentInputs) > 0 && (!bytes.Equal(spent[0][:32], tx.transparentInputs[0].PrevTxHash) ||
+			binary.LittleEndian.Uint32(spent[0][32:]) != tx.transparentInputs[0].PrevTxOutIndex) {
+			t.Errorf("Test %d: unexpected Spent outpoint", i)
+			continue
+		}
+
 		testBinding, _ := hex.DecodeString(tt.bindingSig)
 		if !bytes.Equal(testBinding, tx.bindingSig) {
 			t.Errorf("Test %d: bindingSig %x %x", i, testBinding, tx.bindingSig)
@@ -881,3 +913,106 @@ func subTestShieldedOutputs(testOutputs []outputTestVector, txOutputs []*output,
 
 	return success
 }
+
+func TestCCOutputs(t *testing.T) {
+	identity := "b26820ee0c9b1276aac834cf457026a575dfce84"
+	// A transaction paying to an R-address (P2PKH), and to an identity
+	// (a cryptocondition), then with an output that's not a valid one.
+	p2pkh := "76a914" + "5a4a4bd4a3fed4dd98a1c1adb7ff5e7ad4a05b0e" + "88ac"
+	payToID := "1b" + "0403000101" + "1504" + identity + "cc" +
+		"1b" + "0403000101" + "1504" + identity + "75"
+	badCC := "1b" + "0403000101" + "1504" + identity + "cc"
+	txHex := "01000000" + "01" + strings.Repeat("11", 32) + "00000000" + "00" + "ffffffff" +
+		"03" +
+		"0100000000000000" + "19" + p2pkh +
+		"0200000000000000" + "3a" + payToID +
+		"0300000000000000" + "1d" + badCC +
+		"00000000"
+	rawTx, _ := hex.DecodeString(txHex)
+	tx := NewTransaction()
+	rest, err := tx.ParseFromSlice(rawTx)
+	if err != nil || len(rest) != 0 {
+		t.Fatal("could not parse transaction", err)
+	}
+
+	scripts := tx.CCOutputs()
+	if len(scripts) != 3 || scripts[0] != nil || scripts[1] == nil || scripts[2] != nil {
+		t.Fatal("unexpected CCOutputs")
+	}
+	dests := scripts[1].Params.Destinations
+	if len(dests) != 1 || dests[0].Type != cc.DestIdentity || hex.EncodeToString(dests[0].Bytes) != identity {
+		t.Fatal("unexpected CC destinations")
+	}
+	if s, err := tx.CCOutput(0); s != nil || err != nil {
+		t.Fatal("CCOutput of a P2PKH output should be nil")
+	}
+	if _, err := tx.CCOutput(2); err == nil {
+		t.Fatal("CCOutput of an invalid cryptocondition should fail")
+	}
+	if _, err := tx.CCOutput(3); err == nil {
+		t.Fatal("CCOutput should fail, index out of range")
+	}
+}
+
+func TestFee(t *testing.T) {
+	parse := func(txHex string) *Transaction {
+		rawTx, _ := hex.DecodeString(txHex)
+		tx := NewTransaction()
+		rest, err := tx.ParseFromSlice(rawTx)
+		if err != nil || len(rest) != 0 {
+			t.Fatal("could not parse transaction", err)
+		}
+		return tx
+	}
+	prevTxid := strings.Repeat("11", 32)
+	prevoutValue := func(txid []byte, index uint32) (uint64, bool) {
+		if hex.EncodeToString(txid) != prevTxid || index != 1 {
+			return 0, false
+		}
+		return 10000, true
+	}
+	p2pkh := "19" + "76a914" + "5a4a4bd4a3fed4dd98a1c1adb7ff5e7ad4a05b0e" + "88ac"
+
+	// Spending output 1 (10000) to outputs of 6000 and 3000
+	tx := parse("01000000" + "01" + prevTxid + "01000000" + "00" + "ffffffff" +
+		"02" + "7017000000000000" + p2pkh + "b80b000000000000" + p2pkh +
+		"00000000")
+	if fee, ok := tx.Fee(prevoutValue); !ok || fee != 1000 {
+		t.Fatal("unexpected fee ", fee, ok)
+	}
+	if _, ok := tx.Fee(nil); ok {
+		t.Fatal("fee should be unknown without the prevout")
+	}
+	if tx.ToCompact(0).Fee != 0 {
+		t.Fatal("ToCompact shouldn't know the fee")
+	}
+
+	// From the Sapling pool (valueBalance 1500) to an output of 1000
+	tx = parse("0400008085202f89" + "00" + "01" + "e803000000000000" + p2pkh +
+		"00000000" + "00000000" + "dc05000000000000" + "00" + "00" + "00")
+	if fee, ok := tx.Fee(nil); !ok || fee != 500 || tx.ToCompact(0).Fee != 500 {
+		t.Fatal("unexpected fee ", fee, ok)
+	}
+
+	// Spending 10000 to an output of 1000 and the Sapling pool (8000)
+	tx = parse("0400008085202f89" + "01" + prevTxid + "01000000" + "00" + "ffffffff" +
+		"01" + "e803000000000000" + p2pkh +
+		"00000000" + "00000000" + "c0e0ffffffffffff" + "00" + "00" + "00")
+	if fee, ok := tx.Fee(prevoutValue); !ok || fee != 1000 {
+		t.Fatal("unexpected fee ", fee, ok)
+	}
+
+	// Outputs worth more than the inputs
+	tx = parse("01000000" + "01" + prevTxid + "01000000" + "00" + "ffffffff" +
+		"01" + "1127000000000000" + p2pkh + "00000000")
+	if _, ok := tx.Fee(prevoutValue); ok {
+		t.Fatal("fee should be unknown, outputs exceed inputs")
+	}
+
+	// A coinbase has no fee.
+	tx = parse("01000000" + "01" + strings.Repeat("00", 32) + "ffffffff" + "00" + "ffffffff" +
+		"01" + "1127000000000000" + p2pkh + "00000000")
+	if _, ok := tx.Fee(prevoutValue); ok {
+		t.Fatal("coinbase should have no fee")
+	}
+}
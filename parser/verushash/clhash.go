@@ -0,0 +1,336 @@
+package verushash
+
+// Pure Go port of the portable VerusCLHash in crypto/verus_clhash_portable.cpp.
+// The three solution versions share one implementation; the places where
+// later versions diverge are marked inline.
+
+const (
+	// Solution versions, in sync with SOLUTION_VERUSHHASH_V2* in crypto/verus_clhash.h.
+	solutionVerusHashV2  = 1
+	solutionVerusHashV21 = 3
+	solutionVerusHashV22 = 4
+
+	// verusKeySize is VERUSKEYSIZE, the key length in bytes.
+	verusKeySize = 1024*8 + 40*16
+	// verusKeyMask is the key mask applied to the mutated part of the key,
+	// in units of 16 byte words.
+	verusKeyMask = (1024*8 - 1) >> 4
+)
+
+// clmul64 returns the 128 bit carryless product of a and b.
+func clmul64(a, b uint64) u128 {
+	var r u128
+	for i := uint(0); i < 64; i++ {
+		if a&(1<<i) != 0 {
+			r.lo ^= b << i
+			if i > 0 {
+				r.hi ^= b >> (64 - i)
+			}
+		}
+	}
+	return r
+}
+
+// clmul10 is _mm_clmulepi64_si128(a, a, 0x10).
+func clmul10(a u128) u128 {
+	return clmul64(a.lo, a.hi)
+}
+
+// mulhrs is _mm_mulhrs_epi16.
+func mulhrs(a, b u128) u128 {
+	var r u128
+	for i := uint(0); i < 64; i += 16 {
+		lo := (int32(int16(a.lo>>i))*int32(int16(b.lo>>i)) + 0x4000) >> 15
+		hi := (int32(int16(a.hi>>i))*int32(int16(b.hi>>i)) + 0x4000) >> 15
+		r.lo |= uint64(uint16(lo)) << i
+		r.hi |= uint64(uint16(hi)) << i
+	}
+	return r
+}
+
+// modulo32 returns dividend % divisor in the low 32 bits of a u128, where
+// divisor is the low 32 bits of selector interpreted as signed.
+func modulo32(dividend int64, selector uint64) u128 {
+	divisor := int64(int32(uint32(selector)))
+	return u128{lo: uint64(uint32(dividend % divisor))}
+}
+
+// aes2 is the AES2_EMU macro: two AES rounds on each of s0 and s1 keyed
+// with rc[0:4].
+func aes2(s0, s1 u128, rc []u128) (u128, u128) {
+	s0 = aesenc(s0, rc[0])
+	s1 = aesenc(s1, rc[1])
+	s0 = aesenc(s0, rc[2])
+	s1 = aesenc(s1, rc[3])
+	return s0, s1
+}
+
+// mix2 is the MIX2_EMU macro.
+func mix2(s0, s1 u128) (u128, u128) {
+	return unpacklo32(s0, s1), unpackhi32(s0, s1)
+}
+
+var reductionTable = [16]byte{0, 27, 54, 45, 108, 119, 90, 65, 216, 195, 238, 245, 180, 175, 130, 153}
+
+// precompReduction64 reduces a modulo the irreducible polynomial
+// x^64 + x^4 + x^3 + x + 1.
+func precompReduction64(a u128) uint64 {
+	q2 := clmul64(a.hi, 0x1b)
+	// _mm_shuffle_epi8 of the table by q2 >> 64; only byte 0 can be non-zero
+	var q3 uint64
+	for i := uint(0); i < 64; i += 8 {
+		if idx := byte(q2.hi >> i); idx&0x80 == 0 {
+			q3 |= uint64(reductionTable[idx&0xf]) << i
+		}
+	}
+	return q3 ^ q2.lo ^ a.lo
+}
+
+// verusCLHash mutates key while hashing the 64 byte buffer buf and returns
+// the 64 bit intermediate result used to select the final Haraka key.
+func verusCLHash(key []u128, buf *[4]u128, solutionVersion int) uint64 {
+	acc := clmulWithoutReduction(key, buf, solutionVersion)
+	// lazyLengthHash(1024, 64)
+	acc.lo ^= 1 << 16
+	return precompReduction64(acc)
+}
+
+func clmulWithoutReduction(key []u128, buf *[4]u128, solutionVersion int) u128 {
+	pbuf := *buf
+	if solutionVersion >= solutionVerusHashV21 {
+		pbuf[0] = buf[0].xor(buf[2])
+		pbuf[1] = buf[1].xor(buf[3])
+	}
+
+	acc := key[verusKeyMask+2]
+
+	for i := 0; i < 32; i++ {
+		selector := acc.lo
+
+		// two random locations in the key, which will be mutated and swapped
+		prand := int((selector >> 5) & verusKeyMask)
+		prandex := int((selector >> 32) & verusKeyMask)
+
+		// random start and order of pbuf processing
+		p := int(selector & 3)
+		o := p + 1
+		if selector&1 != 0 {
+			o = p - 1
+		}
+
+		switch selector & 0x1c {
+		case 0:
+			temp1 := key[prandex]
+			temp2 := pbuf[o]
+			acc = clmul10(temp1.xor(temp2)).xor(acc)
+
+			tempa2 := mulhrs(acc, temp1).xor(temp1)
+
+			temp12 := key[prand]
+			key[prand] = tempa2
+
+			temp22 := pbuf[p]
+			acc = clmul10(temp12.xor(temp22)).xor(acc)
+
+			key[prandex] = mulhrs(acc, temp12).xor(temp12)
+		case 4:
+			temp1 := key[prand]
+			temp2 := pbuf[p]
+			acc = clmul10(temp1.xor(temp2)).xor(acc)
+			acc = clmul10(temp2).xor(acc)
+
+			tempa2 := mulhrs(acc, temp1).xor(temp1)
+
+			temp12 := key[prandex]
+			key[prandex] = tempa2
+
+			temp22 := pbuf[o]
+			acc = temp12.xor(temp22).xor(acc)
+
+			key[prand] = mulhrs(acc, temp12).xor(temp12)
+		case 8:
+			temp1 := key[prandex]
+			temp2 := pbuf[p]
+			acc = temp1.xor(temp2).xor(acc)
+
+			tempa2 := mulhrs(acc, temp1).xor(temp1)
+
+			temp12 := key[prand]
+			key[prand] = tempa2
+
+			temp22 := pbuf[o]
+			acc = clmul10(temp12.xor(temp22)).xor(acc)
+			acc = clmul10(temp22).xor(acc)
+
+			key[prandex] = mulhrs(acc, temp12).xor(temp12)
+		case 0xc:
+			temp1 := key[prand]
+			temp2 := pbuf[o]
+			acc = temp1.xor(temp2).xor(acc)
+
+			dividend := int64(acc.lo)
+			acc = modulo32(dividend, selector).xor(acc)
+
+			tempa2 := mulhrs(acc, temp1).xor(temp1)
+
+			if dividend&1 != 0 {
+				temp12 := key[prandex]
+				key[prandex] = tempa2
+
+				temp22 := pbuf[p]
+				acc = clmul10(temp12.xor(temp22)).xor(acc)
+				acc = clmul10(temp22).xor(acc)
+
+				key[prand] = mulhrs(acc, temp12).xor(temp12)
+			} else {
+				tempb3 := key[prandex]
+				key[prandex] = tempa2
+				key[prand] = tempb3
+				if solutionVersion >= solutionVerusHashV22 {
+					acc = pbuf[p].xor(acc)
+				}
+			}
+		case 0x10:
+			// a few AES operations
+			rc := key[prand:]
+			temp1, temp2 := pbuf[o], pbuf[p]
+
+			temp1, temp2 = mix2(aes2(temp1, temp2, rc[0:]))
+			temp1, temp2 = mix2(aes2(temp1, temp2, rc[4:]))
+			temp1, temp2 = mix2(aes2(temp1, temp2, rc[8:]))
+
+			acc = temp1.xor(acc)
+			acc = temp2.xor(acc)
+
+			tempa1 := key[prand]
+			tempa3 := tempa1.xor(mulhrs(acc, tempa1))
+
+			tempa4 := key[prandex]
+			key[prandex] = tempa3
+			key[prand] = tempa4
+		case 0x14:
+			// the monkins loop, between 1 and 8 rounds
+			rounds := selector >> 61
+			rc := prand
+			aesround := 0
+			for {
+				var cl bool
+				if solutionVersion >= solutionVerusHashV21 {
+					cl = selector&(uint64(0x10000000)<<rounds) != 0
+				} else {
+					// the original computes 0x10000000 << rounds as a
+					// 32 bit int, which sign extends at 3 rounds and
+					// shifts out entirely beyond that
+					cl = selector&uint64(int64(int32(uint32(0x10000000)<<rounds))) != 0
+				}
+				if cl {
+					onekey := key[rc]
+					rc++
+					temp2 := pbuf[o]
+					if rounds&1 != 0 {
+						temp2 = pbuf[p]
+					}
+					acc = clmul10(onekey.xor(temp2)).xor(acc)
+				} else {
+					onekey := key[rc]
+					rc++
+					temp2 := pbuf[p]
+					if rounds&1 != 0 {
+						temp2 = pbuf[o]
+					}
+					roundidx := aesround << 2
+					aesround++
+					onekey, temp2 = mix2(aes2(onekey, temp2, key[rc+roundidx:]))
+
+					acc = onekey.xor(acc)
+					acc = temp2.xor(acc)
+				}
+				if rounds == 0 {
+					break
+				}
+				rounds--
+			}
+
+			tempa1 := key[prand]
+			tempa3 := tempa1.xor(mulhrs(acc, tempa1))
+
+			tempa4 := key[prandex]
+			key[prandex] = tempa3
+			key[prand] = tempa4
+		case 0x18:
+			if solutionVersion < solutionVerusHashV21 {
+				temp1 := pbuf[o]
+				temp2 := key[prand]
+				acc = clmul10(temp1.xor(temp2)).xor(acc)
+
+				tempa2 := mulhrs(acc, temp2).xor(temp2)
+
+				tempb3 := key[prandex]
+				key[prandex] = tempa2
+				key[prand] = tempb3
+				break
+			}
+
+			rounds := selector >> 61
+			rc := prand
+			var onekey u128
+			for {
+				if selector&(uint64(0x10000000)<<rounds) != 0 {
+					onekey = key[rc]
+					rc++
+					temp2 := pbuf[o]
+					if rounds&1 != 0 {
+						temp2 = pbuf[p]
+					}
+					add1 := onekey.xor(temp2)
+					if solutionVersion >= solutionVerusHashV22 {
+						onekey = add1
+					}
+					acc = modulo32(int64(add1.lo), selector).xor(acc)
+				} else {
+					onekey = key[rc]
+					rc++
+					temp2 := pbuf[p]
+					if rounds&1 != 0 {
+						temp2 = pbuf[o]
+					}
+					clprod1 := clmul10(onekey.xor(temp2))
+					if solutionVersion >= solutionVerusHashV22 {
+						onekey = clprod1
+					}
+					acc = mulhrs(acc, clprod1).xor(acc)
+				}
+				if rounds == 0 {
+					break
+				}
+				rounds--
+			}
+
+			tempa4 := key[prandex].xor(acc)
+			if solutionVersion >= solutionVerusHashV22 {
+				key[prandex] = onekey
+				key[prand] = tempa4
+			} else {
+				key[prandex] = tempa4
+				key[prand] = onekey
+			}
+		case 0x1c:
+			temp1 := pbuf[p]
+			temp2 := key[prandex]
+			acc = clmul10(temp1.xor(temp2)).xor(acc)
+
+			tempa2 := mulhrs(acc, temp2).xor(temp2)
+
+			tempa3 := key[prand]
+			key[prand] = tempa2
+
+			acc = tempa3.xor(acc)
+			if solutionVersion >= solutionVerusHashV22 {
+				acc = pbuf[o].xor(acc)
+			}
+
+			key[prandex] = mulhrs(acc, tempa3).xor(tempa3)
+		}
+	}
+	return acc
+}
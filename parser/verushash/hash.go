@@ -0,0 +1,111 @@
+package verushash
+
+// Pure Go port of CVerusHash and CVerusHashV2 from crypto/verus_hash.h. It is
+// always compiled so the cgo build can be checked against it; it only backs
+// the exported functions when cgo is disabled.
+
+import "encoding/binary"
+
+// verusHasher is the sponge shared by every VerusHash version: each 32 byte
+// block of input is hashed with the 32 byte chaining value by Haraka512.
+type verusHasher struct {
+	curBuf [64]byte
+	curPos int
+	rc     []u128
+}
+
+func newVerusHasher(rc []u128) *verusHasher {
+	return &verusHasher{rc: rc}
+}
+
+func (h *verusHasher) write(data []byte) {
+	for len(data) > 0 {
+		n := copy(h.curBuf[32+h.curPos:], data)
+		data = data[n:]
+		h.curPos += n
+		if h.curPos == 32 {
+			haraka512(h.curBuf[:32], h.curBuf[:], h.rc)
+			h.curPos = 0
+		}
+	}
+}
+
+// finalize pads the last block with zeros, as CVerusHash::Finalize does.
+func (h *verusHasher) finalize() []byte {
+	hash := make([]byte, 32)
+	if h.curPos == 0 {
+		copy(hash, h.curBuf[:32])
+		return hash
+	}
+	for i := 32 + h.curPos; i < 64; i++ {
+		h.curBuf[i] = 0
+	}
+	haraka512(hash, h.curBuf[:], h.rc)
+	return hash
+}
+
+// fillExtra repeats data over the unused part of the last block.
+func (h *verusHasher) fillExtra(data []byte) {
+	for pos := 32 + h.curPos; pos < 64; {
+		pos += copy(h.curBuf[pos:], data)
+	}
+}
+
+// finalize2b is CVerusHashV2::Finalize2b: the unused part of the last block
+// is filled from a VerusCLHash of the block, keyed by the chaining value, and
+// the block is then hashed with a Haraka512 keyed from the mutated key.
+func (h *verusHasher) finalize2b(solutionVersion int) []byte {
+	h.fillExtra(h.curBuf[:16])
+
+	key := genCLKey(h.curBuf[:32])
+	var buf [4]u128
+	for i := range buf {
+		buf[i] = loadU128(h.curBuf[16*i:])
+	}
+	intermediate := verusCLHash(key, &buf, solutionVersion)
+
+	var extra [8]byte
+	binary.LittleEndian.PutUint64(extra[:], intermediate)
+	h.fillExtra(extra[:])
+
+	hash := make([]byte, 32)
+	haraka512(hash, h.curBuf[:], key[intermediate&verusKeyMask:])
+	return hash
+}
+
+// genCLKey expands seed into a VerusCLHash key by chaining Haraka256.
+func genCLKey(seed []byte) []u128 {
+	var buf [verusKeySize]byte
+	src := seed
+	for i := 0; i < verusKeySize; i += 32 {
+		haraka256(buf[i:], src)
+		src = buf[i : i+32]
+	}
+	key := make([]u128, verusKeySize/16)
+	for i := range key {
+		key[i] = loadU128(buf[16*i:])
+	}
+	return key
+}
+
+// verusHashV1 is the original VerusHash, Haraka512 with zeroed round constants.
+func verusHashV1(data []byte) []byte {
+	h := newVerusHasher(harakaRC0[:])
+	h.write(data)
+	return h.finalize()
+}
+
+// verusHashV2 is VerusHash 2, as hashed by CVerusHashV2Writer: the V2 round
+// constants without the 2b finalization.
+func verusHashV2(data []byte) []byte {
+	h := newVerusHasher(harakaRC[:])
+	h.write(data)
+	return h.finalize()
+}
+
+// verusHashV2b is VerusHash 2b for the given solution version.
+func verusHashV2b(data []byte, solutionVersion int) []byte {
+	h := newVerusHasher(harakaRC[:])
+	h.write(data)
+	return h.finalize2b(solutionVersion)
+}
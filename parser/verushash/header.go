@@ -0,0 +1,238 @@
+package verushash
+
+// Pure Go port of CBlockHeader::GetVerusV2Hash from blockhash.cpp and the
+// parts of solutiondata.h it depends on.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/dchest/blake2b"
+)
+
+const (
+	// verusV2 is CBlockHeader::VERUS_V2, the nVersion of VerusHash 2 headers.
+	verusV2 = 0x00010004
+
+	// Offsets into a serialized CBlockHeader.
+	headerPrevBlock     = 4
+	headerMerkleRoot    = 36
+	headerSaplingRoot   = 68
+	headerTime          = 100
+	headerBits          = 104
+	headerNonce         = 108
+	headerSolutionStart = 140
+
+	// solutionDescriptorSize is sizeof(CPBaaSSolutionDescriptor); the PBaaS
+	// headers follow it in the solution.
+	solutionDescriptorSize = 72
+	// pbaasHeaderSize is sizeof(CPBaaSBlockHeader): a 20 byte chain ID and
+	// a 32 byte pre-header hash.
+	pbaasHeaderSize = 52
+	// headerBaseSize is CConstVerusSolutionVector::HEADER_BASESIZE.
+	headerBaseSize = 143
+
+	// Solution versions from CActivationHeight.
+	activatePBaaSHeader = 5
+
+	solutionPoW = 0x1
+
+	maxSize = 0x02000000
+)
+
+// verusChainID is ASSETCHAINS_CHAINID, the chain ID of VRSC.
+var verusChainID = []byte{
+	0x1a, 0xf5, 0xb8, 0x01, 0x5c, 0x64, 0xd3, 0x9a, 0xb4, 0x4c,
+	0x60, 0xea, 0xd8, 0x31, 0x7f, 0x9f, 0x5a, 0x9b, 0x6c, 0x4c,
+}
+
+var blake2bPersonal = []byte("VerusDefaultHash")
+
+// blockHeader is a serialized CBlockHeader split at its solution.
+type blockHeader struct {
+	raw      []byte // the complete serialized header
+	solution []byte // nSolution, aliasing raw
+}
+
+// parseBlockHeader splits a serialized header, rejecting anything
+// CDataStream would fail to deserialize. Trailing bytes are ignored.
+func parseBlockHeader(serialized []byte) (*blockHeader, bool) {
+	if len(serialized) < headerSolutionStart+1 {
+		return nil, false
+	}
+	rest := serialized[headerSolutionStart:]
+	var length uint64
+	switch rest[0] {
+	case 253:
+		if len(rest) < 3 {
+			return nil, false
+		}
+		length = uint64(binary.LittleEndian.Uint16(rest[1:]))
+		if length < 253 {
+			return nil, false
+		}
+		rest = rest[3:]
+	case 254:
+		if len(rest) < 5 {
+			return nil, false
+		}
+		length = uint64(binary.LittleEndian.Uint32(rest[1:]))
+		if length < 0x10000 {
+			return nil, false
+		}
+		rest = rest[5:]
+	case 255:
+		// always larger than maxSize
+		return nil, false
+	default:
+		length = uint64(rest[0])
+		rest = rest[1:]
+	}
+	if length > maxSize || uint64(len(rest)) < length {
+		return nil, false
+	}
+	end := len(serialized) - len(rest) + int(length)
+	return &blockHeader{
+		raw:      serialized[:end],
+		solution: serialized[end-int(length) : end],
+	}, true
+}
+
+func (h *blockHeader) version() uint32 {
+	return binary.LittleEndian.Uint32(h.raw)
+}
+
+// solutionVersion is CConstVerusSolutionVector::Version.
+func (h *blockHeader) solutionVersion() uint32 {
+	return binary.LittleEndian.Uint32(h.solution)
+}
+
+// hasPBaaSHeader is CConstVerusSolutionVector::HasPBaaSHeader.
+func (h *blockHeader) hasPBaaSHeader() int {
+	if h.solutionVersion() < activatePBaaSHeader {
+		return 0
+	}
+	if h.solution[4]&solutionPoW != 0 {
+		return 1
+	}
+	return -1
+}
+
+// pbaasHeaders returns the PBaaS headers present in the solution.
+func (h *blockHeader) pbaasHeaders() [][]byte {
+	if h.version() != verusV2 || h.hasPBaaSHeader() == 0 {
+		return nil
+	}
+	numHeaders := int(h.solution[5])
+	// ExtraDataLen(nSolution, true), which hasPBaaSHeader has already
+	// established is not zero by version
+	size := len(h.solution)
+	extraLen := size - ((headerBaseSize+size)%32 + numHeaders*pbaasHeaderSize + solutionDescriptorSize)
+	if extraLen < 0 {
+		extraLen = 0
+	}
+	if numHeaders*pbaasHeaderSize > extraLen {
+		numHeaders = extraLen / pbaasHeaderSize
+	}
+	headers := make([][]byte, numHeaders)
+	for i := range headers {
+		start := solutionDescriptorSize + i*pbaasHeaderSize
+		headers[i] = h.solution[start : start+pbaasHeaderSize]
+	}
+	return headers
+}
+
+// preHeaderHash is the BLAKE2b hash of the CPBaaSPreHeader of h.
+func (h *blockHeader) preHeaderHash() []byte {
+	d, _ := blake2b.New(&blake2b.Config{Size: 32, Person: blake2bPersonal})
+	d.Write(h.raw[headerPrevBlock:headerTime])
+	d.Write(h.raw[headerNonce:headerSolutionStart])
+	d.Write(h.raw[headerBits:headerNonce])
+	if h.solutionVersion() >= activatePBaaSHeader {
+		d.Write(h.solution[8:solutionDescriptorSize])
+	} else {
+		d.Write(make([]byte, 64))
+	}
+	return d.Sum(nil)
+}
+
+// checkNonCanonicalData is CBlockHeader::CheckNonCanonicalData: it reports
+// whether a PBaaS header in the solution commits to this header's data.
+func (h *blockHeader) checkNonCanonicalData() bool {
+	headers := h.pbaasHeaders()
+	if len(headers) == 0 {
+		return false
+	}
+	preHeader := h.preHeaderHash()
+	matches := func(chainID []byte) bool {
+		// only the first header for a chain is considered
+		for _, pbh := range headers {
+			if bytes.Equal(pbh[:20], chainID) {
+				return bytes.Equal(pbh[20:], preHeader)
+			}
+		}
+		return false
+	}
+	if matches(verusChainID) {
+		return true
+	}
+	for _, pbh := range headers {
+		if !bytes.Equal(pbh[:20], verusChainID) && matches(pbh[:20]) {
+			return true
+		}
+	}
+	return false
+}
+
+// clearNonCanonicalData returns a copy of the header with everything except
+// the version, time and solution cleared, as for merge mined blocks.
+func (h *blockHeader) clearNonCanonicalData() []byte {
+	raw := make([]byte, len(h.raw))
+	copy(raw, h.raw)
+	zero := func(from, to int) {
+		for i := from; i < to; i++ {
+			raw[i] = 0
+		}
+	}
+	zero(headerPrevBlock, headerTime)
+	zero(headerBits, headerSolutionStart)
+	if h.solutionVersion() >= activatePBaaSHeader {
+		start := len(raw) - len(h.solution)
+		zero(start+8, start+solutionDescriptorSize)
+	}
+	return raw
+}
+
+// verusHashV2b2 is the pure Go equivalent of Verushash::verushash_v2b2,
+// which deserializes a CBlockHeader and returns GetVerusV2Hash(). Headers
+// that fail to deserialize hash to zero.
+func verusHashV2b2(serialized []byte) []byte {
+	h, ok := parseBlockHeader(serialized)
+	if !ok || len(h.solution) < solutionDescriptorSize {
+		return make([]byte, 32)
+	}
+	if isZero(h.raw[headerPrevBlock:headerMerkleRoot]) {
+		// always SHA256D for the genesis block
+		first := sha256.Sum256(h.raw)
+		second := sha256.Sum256(first[:])
+		return second[:]
+	}
+	if h.version() != verusV2 {
+		return verusHashV1(h.raw)
+	}
+	solutionVersion := int(int32(h.solutionVersion()))
+	if h.hasPBaaSHeader() != 0 && h.checkNonCanonicalData() {
+		return verusHashV2b(h.clearNonCanonicalData(), solutionVersion)
+	}
+	return verusHashV2b(h.raw, solutionVersion)
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
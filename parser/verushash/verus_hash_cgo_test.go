@@ -0,0 +1,56 @@
+//go:build cgo
+
+package verushash
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// The tests in this file check the pure Go implementation against the C++
+// library, so they only build with cgo.
+
+func checkHash(t *testing.T, name string, header, want, got []byte) {
+	t.Helper()
+	if !bytes.Equal(want, got) {
+		t.Errorf("%s of %x...: cgo %x, pure Go %x", name, header[:min(16, len(header))], want, got)
+	}
+}
+
+func TestVerusHashDifferential(t *testing.T) {
+	for _, header := range readTestHeaders(t) {
+		checkHash(t, "VerusHash", header, VerusHash(header), verusHashV1(header))
+		checkHash(t, "VerusHash_V2", header, VerusHash_V2(header), verusHashV2(header))
+		checkHash(t, "VerusHash_V2B", header, VerusHash_V2B(header), verusHashV2b(header, solutionVerusHashV2))
+		checkHash(t, "VerusHash_V2B1", header, VerusHash_V2B1(header), verusHashV2b(header, solutionVerusHashV21))
+		checkHash(t, "VerusHash_V2B2", header, VerusHash_V2B2(header), verusHashV2b2(header))
+
+		otherChainID := bytes.Repeat([]byte{0x42}, 20)
+		for _, solutionVersion := range []uint32{1, 2, 3, 4, 5, 6, 7} {
+			for _, descrBits := range []byte{0, solutionPoW} {
+				v2 := verusV2Header(header, solutionVersion, descrBits)
+				checkHash(t, "VerusHash_V2B2", v2, VerusHash_V2B2(v2), verusHashV2b2(v2))
+				for _, chainID := range [][]byte{verusChainID, otherChainID} {
+					pbaas := withPBaaSHeader(v2, chainID)
+					checkHash(t, "VerusHash_V2B2", pbaas, VerusHash_V2B2(pbaas), verusHashV2b2(pbaas))
+				}
+			}
+		}
+	}
+}
+
+func TestVerusHashDifferentialRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		data := make([]byte, r.Intn(256))
+		r.Read(data)
+		checkHash(t, "VerusHash", data, VerusHash(data), verusHashV1(data))
+		checkHash(t, "VerusHash_V2", data, VerusHash_V2(data), verusHashV2(data))
+		checkHash(t, "VerusHash_V2B", data, VerusHash_V2B(data), verusHashV2b(data, solutionVerusHashV2))
+		checkHash(t, "VerusHash_V2B1", data, VerusHash_V2B1(data), verusHashV2b(data, solutionVerusHashV21))
+		if t.Failed() {
+			return
+		}
+	}
+}
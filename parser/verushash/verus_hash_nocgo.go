@@ -0,0 +1,26 @@
+//go:build !cgo
+
+package verushash
+
+// Without cgo the SWIG wrapper is not built and these functions are backed by
+// the pure Go implementation, which gives identical results.
+
+func VerusHash(serializedHeader []byte) []byte {
+	return verusHashV1(serializedHeader)
+}
+
+func VerusHash_V2(serializedHeader []byte) []byte {
+	return verusHashV2(serializedHeader)
+}
+
+func VerusHash_V2B(serializedHeader []byte) []byte {
+	return verusHashV2b(serializedHeader, solutionVerusHashV2)
+}
+
+func VerusHash_V2B1(serializedHeader []byte) []byte {
+	return verusHashV2b(serializedHeader, solutionVerusHashV21)
+}
+
+func VerusHash_V2B2(serializedHeader []byte) []byte {
+	return verusHashV2b2(serializedHeader)
+}
@@ -1,3 +1,5 @@
+//go:build cgo
+
 /* ----------------------------------------------------------------------------
  * This file was automatically generated by SWIG (http://www.swig.org).
  * Version 4.0.2
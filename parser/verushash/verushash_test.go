@@ -0,0 +1,96 @@
+package verushash
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"testing"
+)
+
+func readTestHeaders(t testing.TB) [][]byte {
+	testBlocks, err := os.Open("../../testdata/blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testBlocks.Close()
+
+	var headers [][]byte
+	scan := bufio.NewScanner(testBlocks)
+	scan.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scan.Scan() {
+		blockData, err := hex.DecodeString(scan.Text())
+		if err != nil {
+			t.Fatal(err)
+		}
+		h, ok := parseBlockHeader(blockData)
+		if !ok {
+			t.Fatal("could not parse test block header")
+		}
+		headers = append(headers, h.raw)
+	}
+	if err := scan.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return headers
+}
+
+// verusV2Header returns a copy of header converted to a VerusHash 2 header
+// with the given solution version and descriptor bits.
+func verusV2Header(header []byte, solutionVersion uint32, descrBits byte) []byte {
+	v2 := append([]byte{}, header...)
+	binary.LittleEndian.PutUint32(v2, verusV2)
+	h, _ := parseBlockHeader(v2)
+	binary.LittleEndian.PutUint32(h.solution, solutionVersion)
+	h.solution[4] = descrBits
+	return v2
+}
+
+// withPBaaSHeader returns a copy of header with a PBaaS header for chainID
+// that commits to the header's pre-header.
+func withPBaaSHeader(header []byte, chainID []byte) []byte {
+	pbaas := append([]byte{}, header...)
+	h, _ := parseBlockHeader(pbaas)
+	h.solution[5] = 1
+	copy(h.solution[solutionDescriptorSize:], chainID)
+	copy(h.solution[solutionDescriptorSize+20:], h.preHeaderHash())
+	return pbaas
+}
+
+// These vectors were produced by the C++ library; they run against whichever
+// implementation the build selected.
+func TestVerusHash(t *testing.T) {
+	header := readTestHeaders(t)[0]
+	v2 := verusV2Header(header, solutionVerusHashV22, 0)
+	pbaas := withPBaaSHeader(verusV2Header(header, 7, solutionPoW), verusChainID)
+
+	tests := []struct {
+		name string
+		hash func([]byte) []byte
+		in   []byte
+		want string
+	}{
+		{"VerusHash", VerusHash, nil, "0000000000000000000000000000000000000000000000000000000000000000"},
+		{"VerusHash", VerusHash, header, "8eebcbf6f6d1a8457d6a09f928db8de4b9adfc8b8705528d4de48510bc8357c5"},
+		{"VerusHash_V2B", VerusHash_V2B, nil, "92970bc28c2a721fb6896c3d927af4f0be1279324e5486e7f2b643f590867e59"},
+		{"VerusHash_V2B", VerusHash_V2B, header, "522566e08d07363712a109dd97f6c461522a3025631d6424010c3f26bbc63aeb"},
+		{"VerusHash_V2B1", VerusHash_V2B1, header, "1a3bb50f2483cf1935b96c4f21fdb8380c018f3a19b9646bcf779bdb411f8848"},
+		{"VerusHash_V2B2", VerusHash_V2B2, v2, "03f6883cb70496e8b16f87c5b3546df11030c53db893b96491f75be483f23268"},
+		// the PBaaS header commits to the header, so its non-canonical data is cleared before hashing
+		{"VerusHash_V2B2", VerusHash_V2B2, pbaas, "1a226282f89cae5bc2864a5a70cd37df3906f942e594c5a2da3a6d23b3d11df7"},
+		{"VerusHash_V2B2", VerusHash_V2B2, header[:100], "0000000000000000000000000000000000000000000000000000000000000000"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(tt.hash(tt.in)); got != tt.want {
+			t.Errorf("%s(%d bytes) = %s, want %s", tt.name, len(tt.in), got, tt.want)
+		}
+	}
+}
+
+func BenchmarkVerusHashV2B2(b *testing.B) {
+	header := verusV2Header(readTestHeaders(b)[0], solutionVerusHashV22, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerusHash_V2B2(header)
+	}
+}
@@ -81,6 +81,16 @@ type DarksideStreamerClient interface {
 	AddAddressUtxo(ctx context.Context, in *GetAddressUtxosReply, opts ...grpc.CallOption) (*Empty, error)
 	// Clear the list of GetAddressUtxos entries (can't fail)
 	ClearAddressUtxo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Add a version of an identity, to be returned by GetIdentity() and
+	// GetIdentityHistory() once the active chain reaches its blockHeight.
+	StageIdentity(ctx context.Context, in *IdentityInfo, opts ...grpc.CallOption) (*Empty, error)
+	// Clear the list of identities (can't fail)
+	ClearIdentities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Add (or replace) a currency definition, to be returned by GetCurrency(),
+	// ListCurrencies() and (its bestCurrencyState) GetCurrencyState().
+	StageCurrency(ctx context.Context, in *CurrencyDefinition, opts ...grpc.CallOption) (*Empty, error)
+	// Clear the list of currencies (can't fail)
+	ClearCurrencies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type darksideStreamerClient struct {
@@ -263,6 +273,42 @@ func (c *darksideStreamerClient) ClearAddressUtxo(ctx context.Context, in *Empty
 	return out, nil
 }
 
+func (c *darksideStreamerClient) StageIdentity(ctx context.Context, in *IdentityInfo, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.DarksideStreamer/StageIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *darksideStreamerClient) ClearIdentities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.DarksideStreamer/ClearIdentities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *darksideStreamerClient) StageCurrency(ctx context.Context, in *CurrencyDefinition, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.DarksideStreamer/StageCurrency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *darksideStreamerClient) ClearCurrencies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.DarksideStreamer/ClearCurrencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DarksideStreamerServer is the server API for DarksideStreamer service.
 // All implementations must embed UnimplementedDarksideStreamerServer
 // for forward compatibility
@@ -330,6 +376,16 @@ type DarksideStreamerServer interface {
 	AddAddressUtxo(context.Context, *GetAddressUtxosReply) (*Empty, error)
 	// Clear the list of GetAddressUtxos entries (can't fail)
 	ClearAddressUtxo(context.Context, *Empty) (*Empty, error)
+	// Add a version of an identity, to be returned by GetIdentity() and
+	// GetIdentityHistory() once the active chain reaches its blockHeight.
+	StageIdentity(context.Context, *IdentityInfo) (*Empty, error)
+	// Clear the list of identities (can't fail)
+	ClearIdentities(context.Context, *Empty) (*Empty, error)
+	// Add (or replace) a currency definition, to be returned by GetCurrency(),
+	// ListCurrencies() and (its bestCurrencyState) GetCurrencyState().
+	StageCurrency(context.Context, *CurrencyDefinition) (*Empty, error)
+	// Clear the list of currencies (can't fail)
+	ClearCurrencies(context.Context, *Empty) (*Empty, error)
 	mustEmbedUnimplementedDarksideStreamerServer()
 }
 
@@ -370,6 +426,18 @@ func (UnimplementedDarksideStreamerServer) AddAddressUtxo(context.Context, *GetA
 func (UnimplementedDarksideStreamerServer) ClearAddressUtxo(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearAddressUtxo not implemented")
 }
+func (UnimplementedDarksideStreamerServer) StageIdentity(context.Context, *IdentityInfo) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StageIdentity not implemented")
+}
+func (UnimplementedDarksideStreamerServer) ClearIdentities(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearIdentities not implemented")
+}
+func (UnimplementedDarksideStreamerServer) StageCurrency(context.Context, *CurrencyDefinition) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StageCurrency not implemented")
+}
+func (UnimplementedDarksideStreamerServer) ClearCurrencies(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCurrencies not implemented")
+}
 func (UnimplementedDarksideStreamerServer) mustEmbedUnimplementedDarksideStreamerServer() {}
 
 // UnsafeDarksideStreamerServer may be embedded to opt out of forward compatibility for this service.
@@ -600,6 +668,78 @@ func _DarksideStreamer_ClearAddressUtxo_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DarksideStreamer_StageIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DarksideStreamerServer).StageIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.DarksideStreamer/StageIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DarksideStreamerServer).StageIdentity(ctx, req.(*IdentityInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DarksideStreamer_ClearIdentities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DarksideStreamerServer).ClearIdentities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.DarksideStreamer/ClearIdentities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DarksideStreamerServer).ClearIdentities(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DarksideStreamer_StageCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrencyDefinition)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DarksideStreamerServer).StageCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.DarksideStreamer/StageCurrency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DarksideStreamerServer).StageCurrency(ctx, req.(*CurrencyDefinition))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DarksideStreamer_ClearCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DarksideStreamerServer).ClearCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.DarksideStreamer/ClearCurrencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DarksideStreamerServer).ClearCurrencies(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DarksideStreamer_ServiceDesc is the grpc.ServiceDesc for DarksideStreamer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -639,6 +779,22 @@ var DarksideStreamer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ClearAddressUtxo",
 			Handler:    _DarksideStreamer_ClearAddressUtxo_Handler,
 		},
+		{
+			MethodName: "StageIdentity",
+			Handler:    _DarksideStreamer_StageIdentity_Handler,
+		},
+		{
+			MethodName: "ClearIdentities",
+			Handler:    _DarksideStreamer_ClearIdentities_Handler,
+		},
+		{
+			MethodName: "StageCurrency",
+			Handler:    _DarksideStreamer_StageCurrency_Handler,
+		},
+		{
+			MethodName: "ClearCurrencies",
+			Handler:    _DarksideStreamer_ClearCurrencies_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -24,17 +24,33 @@ type CompactTxStreamerClient interface {
 	GetBlock(ctx context.Context, in *BlockID, opts ...grpc.CallOption) (*CompactBlock, error)
 	// Return a list of consecutive compact blocks
 	GetBlockRange(ctx context.Context, in *BlockRange, opts ...grpc.CallOption) (CompactTxStreamer_GetBlockRangeClient, error)
+	// Return the full headers of a range of consecutive blocks (the poolTypes are ignored)
+	GetBlockHeaderRange(ctx context.Context, in *BlockRange, opts ...grpc.CallOption) (CompactTxStreamer_GetBlockHeaderRangeClient, error)
+	// Return the cached blocks from the given block on (or, if neither height
+	// nor hash is given, none), then each block as it's added, and each reorg
+	SubscribeBlocks(ctx context.Context, in *BlockID, opts ...grpc.CallOption) (CompactTxStreamer_SubscribeBlocksClient, error)
 	// Return the requested full (not compact) transaction (as from zcashd)
 	GetTransaction(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (*RawTransaction, error)
+	// Return a proof that the given transaction is in a block of the best chain
+	GetTransactionProof(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (*TransactionProof, error)
 	// Submit the given transaction to the Zcash network
 	SendTransaction(ctx context.Context, in *RawTransaction, opts ...grpc.CallOption) (*SendResponse, error)
-	// Return the txids corresponding to the given t-address within the given block range
+	// Return the txids corresponding to the given t-address within the given block range.
+	// Here and in the other address rpcs, a t-address is an R-, b- or i-address, or a
+	// VerusID friendly name (such as "alice@"), which is resolved to its i-address.
 	GetTaddressTxids(ctx context.Context, in *TransparentAddressBlockFilter, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressTxidsClient, error)
 	GetTaddressBalance(ctx context.Context, in *AddressList, opts ...grpc.CallOption) (*Balance, error)
 	GetTaddressBalanceStream(ctx context.Context, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressBalanceStreamClient, error)
 	// Return a stream of current Mempool transactions. This will keep the output stream open while
 	// there are mempool transactions. It will close the returned stream when a new block is mined.
 	GetMempoolStream(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolStreamClient, error)
+	// Return the (compact) transactions currently in the mempool, which may be a
+	// few seconds out of date, except those in the Exclude list (those the
+	// client got from an earlier call). Their index is unset.
+	GetMempoolTx(ctx context.Context, in *Exclude, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolTxClient, error)
+	// Return an ADDED event for each transaction currently in the mempool, then
+	// each change to it, across blocks, until the client cancels
+	GetMempoolEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolEventsClient, error)
 	// GetTreeState returns the note commitment tree state corresponding to the given block.
 	// See section 3.7 of the Zcash protocol specification. It returns several other useful
 	// values also (even though they can be obtained using GetBlock).
@@ -43,6 +59,21 @@ type CompactTxStreamerClient interface {
 	GetLatestTreeState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TreeState, error)
 	GetAddressUtxos(ctx context.Context, in *GetAddressUtxosArg, opts ...grpc.CallOption) (*GetAddressUtxosReplyList, error)
 	GetAddressUtxosStream(ctx context.Context, in *GetAddressUtxosArg, opts ...grpc.CallOption) (CompactTxStreamer_GetAddressUtxosStreamClient, error)
+	// Return the given VerusID (as from the getidentity rpc)
+	GetIdentity(ctx context.Context, in *IdentityRequest, opts ...grpc.CallOption) (*IdentityInfo, error)
+	// Return the versions of the given VerusID within a block range (as from
+	// the getidentityhistory rpc)
+	GetIdentityHistory(ctx context.Context, in *IdentityHistoryRequest, opts ...grpc.CallOption) (*IdentityHistory, error)
+	// Return the given currency's definition (as from the getcurrency rpc)
+	GetCurrency(ctx context.Context, in *CurrencyRequest, opts ...grpc.CallOption) (*CurrencyDefinition, error)
+	// Return the definitions of the selected currencies (as from the listcurrencies rpc)
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*CurrencyList, error)
+	// Return the given currency's state at a block height (as from the getcurrencystate rpc)
+	GetCurrencyState(ctx context.Context, in *CurrencyStateRequest, opts ...grpc.CallOption) (*CurrencyState, error)
+	// Return the estimated result of a currency conversion (as from the estimateconversion rpc)
+	EstimateConversion(ctx context.Context, in *ConversionRequest, opts ...grpc.CallOption) (*ConversionEstimate, error)
+	// Return the reserve transfers to the given address in the mempool and recent blocks
+	GetPendingReserveTransfers(ctx context.Context, in *Address, opts ...grpc.CallOption) (*ReserveTransferList, error)
 	// Return information about this lightwalletd instance and the blockchain
 	GetLightdInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LightdInfo, error)
 	// Testing-only, requires lightwalletd --ping-very-insecure (do not enable in production)
@@ -107,6 +138,70 @@ func (x *compactTxStreamerGetBlockRangeClient) Recv() (*CompactBlock, error) {
 	return m, nil
 }
 
+func (c *compactTxStreamerClient) GetBlockHeaderRange(ctx context.Context, in *BlockRange, opts ...grpc.CallOption) (CompactTxStreamer_GetBlockHeaderRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[1], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetBlockHeaderRange", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetBlockHeaderRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetBlockHeaderRangeClient interface {
+	Recv() (*BlockHeader, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetBlockHeaderRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetBlockHeaderRangeClient) Recv() (*BlockHeader, error) {
+	m := new(BlockHeader)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compactTxStreamerClient) SubscribeBlocks(ctx context.Context, in *BlockID, opts ...grpc.CallOption) (CompactTxStreamer_SubscribeBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[2], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/SubscribeBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerSubscribeBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_SubscribeBlocksClient interface {
+	Recv() (*BlockEvent, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerSubscribeBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerSubscribeBlocksClient) Recv() (*BlockEvent, error) {
+	m := new(BlockEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *compactTxStreamerClient) GetTransaction(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (*RawTransaction, error) {
 	out := new(RawTransaction)
 	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTransaction", in, out, opts...)
@@ -116,6 +211,15 @@ func (c *compactTxStreamerClient) GetTransaction(ctx context.Context, in *TxFilt
 	return out, nil
 }
 
+func (c *compactTxStreamerClient) GetTransactionProof(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (*TransactionProof, error) {
+	out := new(TransactionProof)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTransactionProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *compactTxStreamerClient) SendTransaction(ctx context.Context, in *RawTransaction, opts ...grpc.CallOption) (*SendResponse, error) {
 	out := new(SendResponse)
 	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/SendTransaction", in, out, opts...)
@@ -126,7 +230,7 @@ func (c *compactTxStreamerClient) SendTransaction(ctx context.Context, in *RawTr
 }
 
 func (c *compactTxStreamerClient) GetTaddressTxids(ctx context.Context, in *TransparentAddressBlockFilter, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressTxidsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[1], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressTxids", opts...)
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[3], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressTxids", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +271,7 @@ func (c *compactTxStreamerClient) GetTaddressBalance(ctx context.Context, in *Ad
 }
 
 func (c *compactTxStreamerClient) GetTaddressBalanceStream(ctx context.Context, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressBalanceStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[2], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressBalanceStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[4], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressBalanceStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -201,7 +305,7 @@ func (x *compactTxStreamerGetTaddressBalanceStreamClient) CloseAndRecv() (*Balan
 }
 
 func (c *compactTxStreamerClient) GetMempoolStream(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[3], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetMempoolStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[5], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetMempoolStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +336,70 @@ func (x *compactTxStreamerGetMempoolStreamClient) Recv() (*RawTransaction, error
 	return m, nil
 }
 
+func (c *compactTxStreamerClient) GetMempoolTx(ctx context.Context, in *Exclude, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolTxClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[6], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetMempoolTx", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetMempoolTxClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetMempoolTxClient interface {
+	Recv() (*CompactTx, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetMempoolTxClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetMempoolTxClient) Recv() (*CompactTx, error) {
+	m := new(CompactTx)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compactTxStreamerClient) GetMempoolEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[7], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetMempoolEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetMempoolEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetMempoolEventsClient interface {
+	Recv() (*MempoolEvent, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetMempoolEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetMempoolEventsClient) Recv() (*MempoolEvent, error) {
+	m := new(MempoolEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *compactTxStreamerClient) GetTreeState(ctx context.Context, in *BlockID, opts ...grpc.CallOption) (*TreeState, error) {
 	out := new(TreeState)
 	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTreeState", in, out, opts...)
@@ -260,7 +428,7 @@ func (c *compactTxStreamerClient) GetAddressUtxos(ctx context.Context, in *GetAd
 }
 
 func (c *compactTxStreamerClient) GetAddressUtxosStream(ctx context.Context, in *GetAddressUtxosArg, opts ...grpc.CallOption) (CompactTxStreamer_GetAddressUtxosStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[4], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetAddressUtxosStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[8], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetAddressUtxosStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -291,6 +459,69 @@ func (x *compactTxStreamerGetAddressUtxosStreamClient) Recv() (*GetAddressUtxosR
 	return m, nil
 }
 
+func (c *compactTxStreamerClient) GetIdentity(ctx context.Context, in *IdentityRequest, opts ...grpc.CallOption) (*IdentityInfo, error) {
+	out := new(IdentityInfo)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) GetIdentityHistory(ctx context.Context, in *IdentityHistoryRequest, opts ...grpc.CallOption) (*IdentityHistory, error) {
+	out := new(IdentityHistory)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetIdentityHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) GetCurrency(ctx context.Context, in *CurrencyRequest, opts ...grpc.CallOption) (*CurrencyDefinition, error) {
+	out := new(CurrencyDefinition)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetCurrency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*CurrencyList, error) {
+	out := new(CurrencyList)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/ListCurrencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) GetCurrencyState(ctx context.Context, in *CurrencyStateRequest, opts ...grpc.CallOption) (*CurrencyState, error) {
+	out := new(CurrencyState)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetCurrencyState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) EstimateConversion(ctx context.Context, in *ConversionRequest, opts ...grpc.CallOption) (*ConversionEstimate, error) {
+	out := new(ConversionEstimate)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/EstimateConversion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) GetPendingReserveTransfers(ctx context.Context, in *Address, opts ...grpc.CallOption) (*ReserveTransferList, error) {
+	out := new(ReserveTransferList)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetPendingReserveTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *compactTxStreamerClient) GetLightdInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LightdInfo, error) {
 	out := new(LightdInfo)
 	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetLightdInfo", in, out, opts...)
@@ -319,17 +550,33 @@ type CompactTxStreamerServer interface {
 	GetBlock(context.Context, *BlockID) (*CompactBlock, error)
 	// Return a list of consecutive compact blocks
 	GetBlockRange(*BlockRange, CompactTxStreamer_GetBlockRangeServer) error
+	// Return the full headers of a range of consecutive blocks (the poolTypes are ignored)
+	GetBlockHeaderRange(*BlockRange, CompactTxStreamer_GetBlockHeaderRangeServer) error
+	// Return the cached blocks from the given block on (or, if neither height
+	// nor hash is given, none), then each block as it's added, and each reorg
+	SubscribeBlocks(*BlockID, CompactTxStreamer_SubscribeBlocksServer) error
 	// Return the requested full (not compact) transaction (as from zcashd)
 	GetTransaction(context.Context, *TxFilter) (*RawTransaction, error)
+	// Return a proof that the given transaction is in a block of the best chain
+	GetTransactionProof(context.Context, *TxFilter) (*TransactionProof, error)
 	// Submit the given transaction to the Zcash network
 	SendTransaction(context.Context, *RawTransaction) (*SendResponse, error)
-	// Return the txids corresponding to the given t-address within the given block range
+	// Return the txids corresponding to the given t-address within the given block range.
+	// Here and in the other address rpcs, a t-address is an R-, b- or i-address, or a
+	// VerusID friendly name (such as "alice@"), which is resolved to its i-address.
 	GetTaddressTxids(*TransparentAddressBlockFilter, CompactTxStreamer_GetTaddressTxidsServer) error
 	GetTaddressBalance(context.Context, *AddressList) (*Balance, error)
 	GetTaddressBalanceStream(CompactTxStreamer_GetTaddressBalanceStreamServer) error
 	// Return a stream of current Mempool transactions. This will keep the output stream open while
 	// there are mempool transactions. It will close the returned stream when a new block is mined.
 	GetMempoolStream(*Empty, CompactTxStreamer_GetMempoolStreamServer) error
+	// Return the (compact) transactions currently in the mempool, which may be a
+	// few seconds out of date, except those in the Exclude list (those the
+	// client got from an earlier call). Their index is unset.
+	GetMempoolTx(*Exclude, CompactTxStreamer_GetMempoolTxServer) error
+	// Return an ADDED event for each transaction currently in the mempool, then
+	// each change to it, across blocks, until the client cancels
+	GetMempoolEvents(*Empty, CompactTxStreamer_GetMempoolEventsServer) error
 	// GetTreeState returns the note commitment tree state corresponding to the given block.
 	// See section 3.7 of the Zcash protocol specification. It returns several other useful
 	// values also (even though they can be obtained using GetBlock).
@@ -338,6 +585,21 @@ type CompactTxStreamerServer interface {
 	GetLatestTreeState(context.Context, *Empty) (*TreeState, error)
 	GetAddressUtxos(context.Context, *GetAddressUtxosArg) (*GetAddressUtxosReplyList, error)
 	GetAddressUtxosStream(*GetAddressUtxosArg, CompactTxStreamer_GetAddressUtxosStreamServer) error
+	// Return the given VerusID (as from the getidentity rpc)
+	GetIdentity(context.Context, *IdentityRequest) (*IdentityInfo, error)
+	// Return the versions of the given VerusID within a block range (as from
+	// the getidentityhistory rpc)
+	GetIdentityHistory(context.Context, *IdentityHistoryRequest) (*IdentityHistory, error)
+	// Return the given currency's definition (as from the getcurrency rpc)
+	GetCurrency(context.Context, *CurrencyRequest) (*CurrencyDefinition, error)
+	// Return the definitions of the selected currencies (as from the listcurrencies rpc)
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*CurrencyList, error)
+	// Return the given currency's state at a block height (as from the getcurrencystate rpc)
+	GetCurrencyState(context.Context, *CurrencyStateRequest) (*CurrencyState, error)
+	// Return the estimated result of a currency conversion (as from the estimateconversion rpc)
+	EstimateConversion(context.Context, *ConversionRequest) (*ConversionEstimate, error)
+	// Return the reserve transfers to the given address in the mempool and recent blocks
+	GetPendingReserveTransfers(context.Context, *Address) (*ReserveTransferList, error)
 	// Return information about this lightwalletd instance and the blockchain
 	GetLightdInfo(context.Context, *Empty) (*LightdInfo, error)
 	// Testing-only, requires lightwalletd --ping-very-insecure (do not enable in production)
@@ -358,9 +620,18 @@ func (UnimplementedCompactTxStreamerServer) GetBlock(context.Context, *BlockID)
 func (UnimplementedCompactTxStreamerServer) GetBlockRange(*BlockRange, CompactTxStreamer_GetBlockRangeServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetBlockRange not implemented")
 }
+func (UnimplementedCompactTxStreamerServer) GetBlockHeaderRange(*BlockRange, CompactTxStreamer_GetBlockHeaderRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBlockHeaderRange not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) SubscribeBlocks(*BlockID, CompactTxStreamer_SubscribeBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
+}
 func (UnimplementedCompactTxStreamerServer) GetTransaction(context.Context, *TxFilter) (*RawTransaction, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
 }
+func (UnimplementedCompactTxStreamerServer) GetTransactionProof(context.Context, *TxFilter) (*TransactionProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionProof not implemented")
+}
 func (UnimplementedCompactTxStreamerServer) SendTransaction(context.Context, *RawTransaction) (*SendResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
 }
@@ -376,6 +647,12 @@ func (UnimplementedCompactTxStreamerServer) GetTaddressBalanceStream(CompactTxSt
 func (UnimplementedCompactTxStreamerServer) GetMempoolStream(*Empty, CompactTxStreamer_GetMempoolStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetMempoolStream not implemented")
 }
+func (UnimplementedCompactTxStreamerServer) GetMempoolTx(*Exclude, CompactTxStreamer_GetMempoolTxServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetMempoolTx not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetMempoolEvents(*Empty, CompactTxStreamer_GetMempoolEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetMempoolEvents not implemented")
+}
 func (UnimplementedCompactTxStreamerServer) GetTreeState(context.Context, *BlockID) (*TreeState, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTreeState not implemented")
 }
@@ -388,6 +665,27 @@ func (UnimplementedCompactTxStreamerServer) GetAddressUtxos(context.Context, *Ge
 func (UnimplementedCompactTxStreamerServer) GetAddressUtxosStream(*GetAddressUtxosArg, CompactTxStreamer_GetAddressUtxosStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetAddressUtxosStream not implemented")
 }
+func (UnimplementedCompactTxStreamerServer) GetIdentity(context.Context, *IdentityRequest) (*IdentityInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdentity not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetIdentityHistory(context.Context, *IdentityHistoryRequest) (*IdentityHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdentityHistory not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetCurrency(context.Context, *CurrencyRequest) (*CurrencyDefinition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrency not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*CurrencyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCurrencies not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetCurrencyState(context.Context, *CurrencyStateRequest) (*CurrencyState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrencyState not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) EstimateConversion(context.Context, *ConversionRequest) (*ConversionEstimate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateConversion not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetPendingReserveTransfers(context.Context, *Address) (*ReserveTransferList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPendingReserveTransfers not implemented")
+}
 func (UnimplementedCompactTxStreamerServer) GetLightdInfo(context.Context, *Empty) (*LightdInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLightdInfo not implemented")
 }
@@ -464,6 +762,48 @@ func (x *compactTxStreamerGetBlockRangeServer) Send(m *CompactBlock) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CompactTxStreamer_GetBlockHeaderRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockRange)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetBlockHeaderRange(m, &compactTxStreamerGetBlockHeaderRangeServer{stream})
+}
+
+type CompactTxStreamer_GetBlockHeaderRangeServer interface {
+	Send(*BlockHeader) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetBlockHeaderRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetBlockHeaderRangeServer) Send(m *BlockHeader) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompactTxStreamer_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).SubscribeBlocks(m, &compactTxStreamerSubscribeBlocksServer{stream})
+}
+
+type CompactTxStreamer_SubscribeBlocksServer interface {
+	Send(*BlockEvent) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerSubscribeBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerSubscribeBlocksServer) Send(m *BlockEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _CompactTxStreamer_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TxFilter)
 	if err := dec(in); err != nil {
@@ -482,6 +822,24 @@ func _CompactTxStreamer_GetTransaction_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CompactTxStreamer_GetTransactionProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetTransactionProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTransactionProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetTransactionProof(ctx, req.(*TxFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CompactTxStreamer_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RawTransaction)
 	if err := dec(in); err != nil {
@@ -586,6 +944,48 @@ func (x *compactTxStreamerGetMempoolStreamServer) Send(m *RawTransaction) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CompactTxStreamer_GetMempoolTx_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Exclude)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetMempoolTx(m, &compactTxStreamerGetMempoolTxServer{stream})
+}
+
+type CompactTxStreamer_GetMempoolTxServer interface {
+	Send(*CompactTx) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetMempoolTxServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetMempoolTxServer) Send(m *CompactTx) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompactTxStreamer_GetMempoolEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetMempoolEvents(m, &compactTxStreamerGetMempoolEventsServer{stream})
+}
+
+type CompactTxStreamer_GetMempoolEventsServer interface {
+	Send(*MempoolEvent) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetMempoolEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetMempoolEventsServer) Send(m *MempoolEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _CompactTxStreamer_GetTreeState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlockID)
 	if err := dec(in); err != nil {
@@ -661,6 +1061,132 @@ func (x *compactTxStreamerGetAddressUtxosStreamServer) Send(m *GetAddressUtxosRe
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CompactTxStreamer_GetIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetIdentity(ctx, req.(*IdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_GetIdentityHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetIdentityHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetIdentityHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetIdentityHistory(ctx, req.(*IdentityHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_GetCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetCurrency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetCurrency(ctx, req.(*CurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/ListCurrencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_GetCurrencyState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrencyStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetCurrencyState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetCurrencyState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetCurrencyState(ctx, req.(*CurrencyStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_EstimateConversion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConversionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).EstimateConversion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/EstimateConversion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).EstimateConversion(ctx, req.(*ConversionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_GetPendingReserveTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Address)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetPendingReserveTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetPendingReserveTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetPendingReserveTransfers(ctx, req.(*Address))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CompactTxStreamer_GetLightdInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -716,6 +1242,10 @@ var CompactTxStreamer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransaction",
 			Handler:    _CompactTxStreamer_GetTransaction_Handler,
 		},
+		{
+			MethodName: "GetTransactionProof",
+			Handler:    _CompactTxStreamer_GetTransactionProof_Handler,
+		},
 		{
 			MethodName: "SendTransaction",
 			Handler:    _CompactTxStreamer_SendTransaction_Handler,
@@ -736,6 +1266,34 @@ var CompactTxStreamer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAddressUtxos",
 			Handler:    _CompactTxStreamer_GetAddressUtxos_Handler,
 		},
+		{
+			MethodName: "GetIdentity",
+			Handler:    _CompactTxStreamer_GetIdentity_Handler,
+		},
+		{
+			MethodName: "GetIdentityHistory",
+			Handler:    _CompactTxStreamer_GetIdentityHistory_Handler,
+		},
+		{
+			MethodName: "GetCurrency",
+			Handler:    _CompactTxStreamer_GetCurrency_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _CompactTxStreamer_ListCurrencies_Handler,
+		},
+		{
+			MethodName: "GetCurrencyState",
+			Handler:    _CompactTxStreamer_GetCurrencyState_Handler,
+		},
+		{
+			MethodName: "EstimateConversion",
+			Handler:    _CompactTxStreamer_EstimateConversion_Handler,
+		},
+		{
+			MethodName: "GetPendingReserveTransfers",
+			Handler:    _CompactTxStreamer_GetPendingReserveTransfers_Handler,
+		},
 		{
 			MethodName: "GetLightdInfo",
 			Handler:    _CompactTxStreamer_GetLightdInfo_Handler,
@@ -751,6 +1309,16 @@ var CompactTxStreamer_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CompactTxStreamer_GetBlockRange_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetBlockHeaderRange",
+			Handler:       _CompactTxStreamer_GetBlockHeaderRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _CompactTxStreamer_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetTaddressTxids",
 			Handler:       _CompactTxStreamer_GetTaddressTxids_Handler,
@@ -766,6 +1334,16 @@ var CompactTxStreamer_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CompactTxStreamer_GetMempoolStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetMempoolTx",
+			Handler:       _CompactTxStreamer_GetMempoolTx_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetMempoolEvents",
+			Handler:       _CompactTxStreamer_GetMempoolEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetAddressUtxosStream",
 			Handler:       _CompactTxStreamer_GetAddressUtxosStream_Handler,